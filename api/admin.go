@@ -0,0 +1,221 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"workspace/customTypes"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes of the operators, behind the bearer token of admin_token
+func adminRoutes(router *gin.Engine) {
+	admin := router.Group("/admin", requireAdmin)
+	admin.POST("/webhooks", createWebhook)
+	admin.GET("/webhooks", getWebhooks)
+	admin.GET("/webhooks/:id", getWebhook)
+	admin.GET("/webhooks/:id/deliveries", getWebhookDeliveries)
+	admin.POST("/webhooks/:id/test", testWebhook)
+	admin.POST("/webhooks/:id/disable", disableWebhook)
+}
+
+func requireAdmin(c *gin.Context) {
+	if cfg.AdminToken == "" {
+		abortWithError(c, http.StatusUnauthorized, codeUnauthorized, "the admin routes are disabled, set admin_token to enable them")
+		return
+	}
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(cfg.AdminToken)) != 1 {
+		c.Header("WWW-Authenticate", `Bearer realm="admin"`)
+		abortWithError(c, http.StatusUnauthorized, codeUnauthorized, "expected the admin token as a bearer token")
+		return
+	}
+}
+
+// Body of a new webhook, without a secret one is generated
+type webhookParams struct {
+	URL        string `json:"url"`
+	Collection string `json:"collection"`
+	Address    string `json:"address"`
+	Tag        string `json:"tag"`
+	Secret     string `json:"secret"`
+}
+
+// Register a webhook for the transfers of the chain parameter, the response is the only one with its secret
+func createWebhook(c *gin.Context) {
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+	var params webhookParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		invalidParameter(c, "expected a JSON body with a url")
+		return
+	}
+	target, err := url.Parse(params.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		invalidParameter(c, "invalid url "+strconv.Quote(params.URL)+", expected an absolute http or https url")
+		return
+	}
+	webhook := customTypes.WebhookStruct{ChainId: chainId, URL: params.URL, Tag: params.Tag, Secret: params.Secret}
+	if params.Collection != "" {
+		webhook.Collection, ok = validAddress(c, "collection", params.Collection)
+		if !ok {
+			return
+		}
+	}
+	if params.Address != "" {
+		webhook.Address, ok = validAddress(c, "address", params.Address)
+		if !ok {
+			return
+		}
+	}
+	if webhook.Tag != "" && webhook.Tag != "mint" && webhook.Tag != "transfer" && webhook.Tag != "burn" {
+		invalidParameter(c, "invalid tag "+strconv.Quote(webhook.Tag)+", expected mint, transfer or burn")
+		return
+	}
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		_, err = rand.Read(secret)
+		if err != nil {
+			internalError(c, err)
+			return
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	} else if len(webhook.Secret) < 16 {
+		invalidParameter(c, "invalid secret, expected at least 16 characters")
+		return
+	}
+
+	webhook, err = store.InsertWebhook(c.Request.Context(), webhook)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": webhook})
+}
+
+func getWebhooks(c *gin.Context) {
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	webhooks, next, err := store.SelectWebhooks(c.Request.Context(), page)
+	listResponse(c, webhooks, next, err)
+}
+
+func getWebhook(c *gin.Context) {
+	id, ok := webhookIdParam(c)
+	if !ok {
+		return
+	}
+
+	webhook, found, err := store.SelectWebhook(c.Request.Context(), id)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	if !found {
+		abortWithError(c, http.StatusNotFound, codeNotFound, "unknown webhook")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": webhook})
+}
+
+// Deliveries of a webhook with the history of their attempts, the last first
+func getWebhookDeliveries(c *gin.Context) {
+	id, ok := webhookIdParam(c)
+	if !ok {
+		return
+	}
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	_, found, err := store.SelectWebhook(c.Request.Context(), id)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	if !found {
+		abortWithError(c, http.StatusNotFound, codeNotFound, "unknown webhook")
+		return
+	}
+	deliveries, next, err := store.SelectWebhookDeliveries(c.Request.Context(), id, page)
+	listResponse(c, deliveries, next, err)
+}
+
+// Send a test event at once and reply with its delivery, it is not retried
+func testWebhook(c *gin.Context) {
+	id, ok := webhookIdParam(c)
+	if !ok {
+		return
+	}
+
+	payload, err := json.Marshal(webhookEvent{WebhookId: id, streamMessage: streamMessage{Type: "test"}})
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	task, found, err := store.InsertWebhookDelivery(c.Request.Context(), id, "test", payload)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	if !found {
+		abortWithError(c, http.StatusNotFound, codeNotFound, "unknown webhook")
+		return
+	}
+	attempt, err := deliver(c.Request.Context(), task, false)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+
+	delivery := task.WebhookDeliveryStruct
+	delivery.Status = "delivered"
+	if attempt.Error != "" {
+		delivery.Status = "failed"
+	}
+	delivery.Attempts = 1
+	delivery.History = []customTypes.WebhookAttemptStruct{attempt}
+	c.JSON(http.StatusOK, gin.H{"data": delivery})
+}
+
+// Stop a webhook, its pending deliveries are not sent
+func disableWebhook(c *gin.Context) {
+	id, ok := webhookIdParam(c)
+	if !ok {
+		return
+	}
+
+	found, err := store.DisableWebhook(c.Request.Context(), id)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	if !found {
+		abortWithError(c, http.StatusNotFound, codeNotFound, "unknown webhook")
+		return
+	}
+	getWebhook(c)
+}
+
+func webhookIdParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		invalidParameter(c, "invalid id "+strconv.Quote(c.Param("id"))+", expected the id of a webhook")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"api/config"
+	"workspace/customTypes"
+	"workspace/database"
+	"workspace/database/dbtest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Serve the router on a new SQLite database written by the indexer store, the indexing starts at block 100
+func startAPI(t *testing.T) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	defaults := config.Default()
+	cfg = &defaults
+	cfg.DefaultChainID = dbtest.Chain
+	var err error
+	store, err = database.StartDatabase("sqlite", filepath.Join(t.TempDir(), "segment.db"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	_, err = store.SelectBlock(context.Background(), dbtest.Chain, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(newRouter())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Index a few blocks like the indexer does: one by one, then a scanned range
+func seed(t *testing.T) {
+	ctx := context.Background()
+	deployed := dbtest.MintBlock(100, "1", "2")
+	deployed.Collections = []customTypes.ERC721CollectionStruct{dbtest.Deployment(100)}
+	for _, data := range []customTypes.BlockData{deployed, dbtest.ERC1155MintBlock(101, "7", "10")} {
+		err := store.ApplyBlock(ctx, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	sale := customTypes.BlockData{Block: dbtest.Block(105), Txs: []customTypes.ERC721TxStruct{dbtest.Transfer(105, 0, "transfer", dbtest.Alice, dbtest.Bob, "1")}}
+	sale.Txs[0].Price, sale.Txs[0].PriceSource, sale.Txs[0].Confidence = "1000", "native", "high"
+	err := store.ApplyRange(ctx, []customTypes.BlockData{sale}, dbtest.Block(110))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// GET path and decode its JSON body into response
+func get(t *testing.T, server *httptest.Server, path string, status int, response any) {
+	t.Helper()
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != status {
+		t.Fatalf("GET %s answered %d, expected %d: %s", path, resp.StatusCode, status, body)
+	}
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		t.Fatalf("GET %s: %v in %s", path, err, body)
+	}
+}
+
+type page[T any] struct {
+	Data []T    `json:"data"`
+	Next string `json:"next"`
+}
+
+// The API reads what the indexer wrote, through the same schema
+func TestIndexedBlocksAreServed(t *testing.T) {
+	server := startAPI(t)
+	seed(t)
+	collection := dbtest.Collection.Hex()
+
+	var nft struct{ Data customTypes.NFTStruct }
+	get(t, server, "/nft/"+collection+"/1", http.StatusOK, &nft)
+	if nft.Data.Owner != dbtest.Bob || nft.Data.MintBlockNumber != 100 || nft.Data.URI != "ipfs://Qm/1" {
+		t.Errorf("nft %+v", nft.Data)
+	}
+
+	var history page[customTypes.ERC721TxStruct]
+	get(t, server, "/nft/history/"+collection+"/1", http.StatusOK, &history)
+	if len(history.Data) != 2 || history.Data[0].Tag != "transfer" || history.Data[0].Price != "1000" || history.Data[1].Tag != "mint" {
+		t.Errorf("history %+v", history.Data)
+	}
+
+	// Pages of one row follow each other through their cursor
+	var first, second page[customTypes.NFTStruct]
+	get(t, server, "/collection/"+collection+"?limit=1", http.StatusOK, &first)
+	if len(first.Data) != 1 || first.Next == "" || first.Data[0].TokenId != "2" {
+		t.Fatalf("first page %+v", first)
+	}
+	get(t, server, "/collection/"+collection+"?limit=1&cursor="+first.Next, http.StatusOK, &second)
+	if len(second.Data) != 1 || second.Next != "" || second.Data[0].TokenId != "1" {
+		t.Errorf("second page %+v", second)
+	}
+
+	var owned page[customTypes.NFTStruct]
+	get(t, server, "/address/"+dbtest.Alice.Hex(), http.StatusOK, &owned)
+	if len(owned.Data) != 1 || owned.Data[0].TokenId != "2" {
+		t.Errorf("nfts of alice %+v", owned.Data)
+	}
+	var transfers page[customTypes.ERC721TxStruct]
+	get(t, server, "/address/history/"+dbtest.Bob.Hex(), http.StatusOK, &transfers)
+	if len(transfers.Data) != 1 || transfers.Data[0].BlockNumber != 105 {
+		t.Errorf("transfers of bob %+v", transfers.Data)
+	}
+
+	var token struct {
+		Data customTypes.ERC1155SupplyStruct
+	}
+	get(t, server, "/erc1155/nft/"+dbtest.ERC1155.Hex()+"/7", http.StatusOK, &token)
+	if token.Data.Supply != "10" || token.Data.MintBlockNumber != 101 {
+		t.Errorf("erc1155 token %+v", token.Data)
+	}
+	var balances page[customTypes.ERC1155BalanceStruct]
+	get(t, server, "/erc1155/address/"+dbtest.Alice.Hex(), http.StatusOK, &balances)
+	if len(balances.Data) != 1 || balances.Data[0].Balance != "10" || balances.Data[0].URI != "ipfs://Qm/{id}" {
+		t.Errorf("erc1155 balances %+v", balances.Data)
+	}
+}
+
+func TestUnknownRowsAreNotFound(t *testing.T) {
+	server := startAPI(t)
+	seed(t)
+
+	var response struct{ Error apiError }
+	get(t, server, "/nft/"+dbtest.Collection.Hex()+"/3", http.StatusNotFound, &response)
+	if response.Error.Code != codeNotFound {
+		t.Errorf("error %+v", response.Error)
+	}
+	get(t, server, "/collection/"+dbtest.Collection.Hex()+"?cursor=abc", http.StatusBadRequest, &response)
+	if response.Error.Code != codeInvalidCursor {
+		t.Errorf("error %+v", response.Error)
+	}
+	// Another chain shares the database without seeing the rows of this one
+	var nfts page[customTypes.NFTStruct]
+	get(t, server, "/collection/"+dbtest.Collection.Hex()+"?chain=2", http.StatusOK, &nfts)
+	if len(nfts.Data) != 0 {
+		t.Errorf("nfts of chain 2 %+v", nfts.Data)
+	}
+}
+
+// The database errors are logged, clients only get their code
+func TestInternalErrorsAreHidden(t *testing.T) {
+	server := startAPI(t)
+	store.Close()
+
+	var response struct{ Error apiError }
+	get(t, server, "/nft/"+dbtest.Collection.Hex()+"/1", http.StatusInternalServerError, &response)
+	if response.Error.Code != codeInternal || response.Error.Message != "internal error" {
+		t.Errorf("error %+v", response.Error)
+	}
+}
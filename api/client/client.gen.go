@@ -0,0 +1,5833 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	AdminTokenScopes = "AdminToken.Scopes"
+)
+
+// Defines values for CollectionWindowWindow.
+const (
+	CollectionWindowWindowAll  CollectionWindowWindow = "all"
+	CollectionWindowWindowN1h  CollectionWindowWindow = "1h"
+	CollectionWindowWindowN24h CollectionWindowWindow = "24h"
+	CollectionWindowWindowN30d CollectionWindowWindow = "30d"
+	CollectionWindowWindowN7d  CollectionWindowWindow = "7d"
+)
+
+// Defines values for ERC1155TransferConfidence.
+const (
+	ERC1155TransferConfidenceHigh   ERC1155TransferConfidence = "high"
+	ERC1155TransferConfidenceLow    ERC1155TransferConfidence = "low"
+	ERC1155TransferConfidenceMedium ERC1155TransferConfidence = "medium"
+)
+
+// Defines values for ERC1155TransferTag.
+const (
+	ERC1155TransferTagBurn     ERC1155TransferTag = "burn"
+	ERC1155TransferTagMint     ERC1155TransferTag = "mint"
+	ERC1155TransferTagTransfer ERC1155TransferTag = "transfer"
+)
+
+// Defines values for ErrorErrorCode.
+const (
+	ErrorErrorCodeInternalError    ErrorErrorCode = "internal_error"
+	ErrorErrorCodeInvalidAddress   ErrorErrorCode = "invalid_address"
+	ErrorErrorCodeInvalidCursor    ErrorErrorCode = "invalid_cursor"
+	ErrorErrorCodeInvalidParameter ErrorErrorCode = "invalid_parameter"
+	ErrorErrorCodeInvalidTokenId   ErrorErrorCode = "invalid_token_id"
+	ErrorErrorCodeNotFound         ErrorErrorCode = "not_found"
+	ErrorErrorCodeUnauthorized     ErrorErrorCode = "unauthorized"
+	ErrorErrorCodeUnavailable      ErrorErrorCode = "unavailable"
+)
+
+// Defines values for HealthDatabase.
+const (
+	Down HealthDatabase = "down"
+	Up   HealthDatabase = "up"
+)
+
+// Defines values for HealthStatus.
+const (
+	Ok HealthStatus = "ok"
+)
+
+// Defines values for NFTMetadataStatus.
+const (
+	NFTMetadataStatusDone    NFTMetadataStatus = "done"
+	NFTMetadataStatusFailed  NFTMetadataStatus = "failed"
+	NFTMetadataStatusPending NFTMetadataStatus = "pending"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	Ready ReadinessStatus = "ready"
+)
+
+// Defines values for RefreshAcceptedDataStatus.
+const (
+	RefreshAcceptedDataStatusPending RefreshAcceptedDataStatus = "pending"
+)
+
+// Defines values for StreamEventType.
+const (
+	StreamEventTypeReady    StreamEventType = "ready"
+	StreamEventTypeRollback StreamEventType = "rollback"
+	StreamEventTypeTransfer StreamEventType = "transfer"
+)
+
+// Defines values for TransferConfidence.
+const (
+	TransferConfidenceHigh   TransferConfidence = "high"
+	TransferConfidenceLow    TransferConfidence = "low"
+	TransferConfidenceMedium TransferConfidence = "medium"
+)
+
+// Defines values for TransferTag.
+const (
+	TransferTagBurn     TransferTag = "burn"
+	TransferTagMint     TransferTag = "mint"
+	TransferTagTransfer TransferTag = "transfer"
+)
+
+// Defines values for WebhookTag.
+const (
+	WebhookTagBurn     WebhookTag = "burn"
+	WebhookTagMint     WebhookTag = "mint"
+	WebhookTagTransfer WebhookTag = "transfer"
+)
+
+// Defines values for WebhookCreateTag.
+const (
+	WebhookCreateTagBurn     WebhookCreateTag = "burn"
+	WebhookCreateTagMint     WebhookCreateTag = "mint"
+	WebhookCreateTagTransfer WebhookCreateTag = "transfer"
+)
+
+// Defines values for WebhookDeliveryEvent.
+const (
+	WebhookDeliveryEventRollback WebhookDeliveryEvent = "rollback"
+	WebhookDeliveryEventTest     WebhookDeliveryEvent = "test"
+	WebhookDeliveryEventTransfer WebhookDeliveryEvent = "transfer"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+	Sending   WebhookDeliveryStatus = "sending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	WebhookEventTypeRollback WebhookEventType = "rollback"
+	WebhookEventTypeTest     WebhookEventType = "test"
+	WebhookEventTypeTransfer WebhookEventType = "transfer"
+)
+
+// Defines values for GetCollectionStatsParamsWindow.
+const (
+	GetCollectionStatsParamsWindowAll  GetCollectionStatsParamsWindow = "all"
+	GetCollectionStatsParamsWindowN1h  GetCollectionStatsParamsWindow = "1h"
+	GetCollectionStatsParamsWindowN24h GetCollectionStatsParamsWindow = "24h"
+	GetCollectionStatsParamsWindowN30d GetCollectionStatsParamsWindow = "30d"
+	GetCollectionStatsParamsWindowN7d  GetCollectionStatsParamsWindow = "7d"
+)
+
+// Defines values for GetCollectionStatsSeriesParamsPeriod.
+const (
+	Day  GetCollectionStatsSeriesParamsPeriod = "day"
+	Hour GetCollectionStatsSeriesParamsPeriod = "hour"
+)
+
+// Defines values for GetCollectionNftsParamsSort.
+const (
+	GetCollectionNftsParamsSortMint   GetCollectionNftsParamsSort = "mint"
+	GetCollectionNftsParamsSortRarity GetCollectionNftsParamsSort = "rarity"
+)
+
+// Defines values for StreamTransfersParamsTag.
+const (
+	StreamTransfersParamsTagBurn     StreamTransfersParamsTag = "burn"
+	StreamTransfersParamsTagMint     StreamTransfersParamsTag = "mint"
+	StreamTransfersParamsTagTransfer StreamTransfersParamsTag = "transfer"
+)
+
+// Defines values for StreamTransfersWebSocketParamsTag.
+const (
+	StreamTransfersWebSocketParamsTagBurn     StreamTransfersWebSocketParamsTag = "burn"
+	StreamTransfersWebSocketParamsTagMint     StreamTransfersWebSocketParamsTag = "mint"
+	StreamTransfersWebSocketParamsTagTransfer StreamTransfersWebSocketParamsTag = "transfer"
+)
+
+// Address Lowercase hex address
+type Address = string
+
+// CollectionWindow defines model for CollectionWindow.
+type CollectionWindow struct {
+	// AveragePrice Null without sales
+	AveragePrice *string     `json:"averagePrice"`
+	Burns        int         `json:"burns"`
+	Buyers       int         `json:"buyers"`
+	Currencies   []SaleStats `json:"currencies"`
+
+	// Floor Null without sales
+	Floor *string `json:"floor"`
+
+	// Holders Null when it is not known
+	Holders    *int `json:"holders"`
+	Mints      int  `json:"mints"`
+	OwnerCount int  `json:"ownerCount"`
+	SaleCount  int  `json:"saleCount"`
+	Sellers    int  `json:"sellers"`
+	Transfers  int  `json:"transfers"`
+	TxCount    int  `json:"txCount"`
+
+	// Volume Decimal uint256
+	Volume Uint256                `json:"volume"`
+	Window CollectionWindowWindow `json:"window"`
+}
+
+// CollectionWindowWindow defines model for CollectionWindow.Window.
+type CollectionWindowWindow string
+
+// CollectionWindowResponse defines model for CollectionWindowResponse.
+type CollectionWindowResponse struct {
+	Data CollectionWindow `json:"data"`
+}
+
+// ERC1155Balance defines model for ERC1155Balance.
+type ERC1155Balance struct {
+	// Balance Decimal uint256
+	Balance Uint256 `json:"balance"`
+	ChainId int64   `json:"chainId"`
+
+	// Collection Lowercase hex address
+	Collection Address `json:"collection"`
+
+	// Holder Lowercase hex address
+	Holder Address `json:"holder"`
+
+	// TokenId Decimal uint256
+	TokenId Uint256 `json:"tokenId"`
+	Uri     string  `json:"uri"`
+}
+
+// ERC1155BalancePage defines model for ERC1155BalancePage.
+type ERC1155BalancePage struct {
+	Data []ERC1155Balance `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// ERC1155CollectionStats defines model for ERC1155CollectionStats.
+type ERC1155CollectionStats struct {
+	OwnerCount int `json:"ownerCount"`
+	TokenCount int `json:"tokenCount"`
+
+	// TxCount Transactions of the last 24 hours
+	TxCount int `json:"txCount"`
+	Volume  int `json:"volume"`
+}
+
+// ERC1155CollectionStatsResponse defines model for ERC1155CollectionStatsResponse.
+type ERC1155CollectionStatsResponse struct {
+	Data ERC1155CollectionStats `json:"data"`
+}
+
+// ERC1155Token defines model for ERC1155Token.
+type ERC1155Token struct {
+	ChainId int64 `json:"chainId"`
+
+	// Collection Lowercase hex address
+	Collection      Address `json:"collection"`
+	MintBlockNumber int64   `json:"mintBlockNumber"`
+	MintTimestamp   int64   `json:"mintTimestamp"`
+	MintTxHash      string  `json:"mintTxHash"`
+
+	// Supply Decimal uint256
+	Supply Uint256 `json:"supply"`
+
+	// TokenId Decimal uint256
+	TokenId Uint256 `json:"tokenId"`
+	Uri     string  `json:"uri"`
+}
+
+// ERC1155TokenPage defines model for ERC1155TokenPage.
+type ERC1155TokenPage struct {
+	Data []ERC1155Token `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// ERC1155TokenResponse defines model for ERC1155TokenResponse.
+type ERC1155TokenResponse struct {
+	Data ERC1155Token `json:"data"`
+}
+
+// ERC1155Transfer defines model for ERC1155Transfer.
+type ERC1155Transfer struct {
+	// Amount Decimal uint256
+	Amount Uint256 `json:"amount"`
+
+	// BatchIndex Index of the id in a TransferBatch
+	BatchIndex  int   `json:"batchIndex"`
+	BlockNumber int64 `json:"blockNumber"`
+	ChainId     int64 `json:"chainId"`
+
+	// Collection Lowercase hex address
+	Collection Address                    `json:"collection"`
+	Confidence *ERC1155TransferConfidence `json:"confidence,omitempty"`
+
+	// Currency Lowercase hex address
+	Currency Address `json:"currency"`
+
+	// From Lowercase hex address
+	From     Address `json:"from"`
+	LogIndex int     `json:"logIndex"`
+
+	// Operator Lowercase hex address
+	Operator Address `json:"operator"`
+
+	// Price Price of one token of the transfer inferred from the payments of the transaction, absent when none was found
+	Price       *string            `json:"price,omitempty"`
+	PriceSource *string            `json:"priceSource,omitempty"`
+	Tag         ERC1155TransferTag `json:"tag"`
+	Timestamp   int64              `json:"timestamp"`
+
+	// To Lowercase hex address
+	To Address `json:"to"`
+
+	// TokenId Decimal uint256
+	TokenId Uint256 `json:"tokenId"`
+	TxHash  string  `json:"txHash"`
+}
+
+// ERC1155TransferConfidence defines model for ERC1155Transfer.Confidence.
+type ERC1155TransferConfidence string
+
+// ERC1155TransferTag defines model for ERC1155Transfer.Tag.
+type ERC1155TransferTag string
+
+// ERC1155TransferPage defines model for ERC1155TransferPage.
+type ERC1155TransferPage struct {
+	Data []ERC1155Transfer `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Error struct {
+		Code    ErrorErrorCode `json:"code"`
+		Message string         `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorErrorCode defines model for Error.Error.Code.
+type ErrorErrorCode string
+
+// GraphQLRequest defines model for GraphQLRequest.
+type GraphQLRequest struct {
+	OperationName *string                 `json:"operationName,omitempty"`
+	Query         string                  `json:"query"`
+	Variables     *map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse Errors of the query are returned in errors with a 200
+type GraphQLResponse struct {
+	Data   *map[string]interface{} `json:"data"`
+	Errors *[]struct {
+		Locations *[]struct {
+			Column *int `json:"column,omitempty"`
+			Line   *int `json:"line,omitempty"`
+		} `json:"locations,omitempty"`
+		Message string         `json:"message"`
+		Path    *[]interface{} `json:"path,omitempty"`
+	} `json:"errors,omitempty"`
+}
+
+// Health defines model for Health.
+type Health struct {
+	Database HealthDatabase `json:"database"`
+	Status   HealthStatus   `json:"status"`
+}
+
+// HealthDatabase defines model for Health.Database.
+type HealthDatabase string
+
+// HealthStatus defines model for Health.Status.
+type HealthStatus string
+
+// NFT defines model for NFT.
+type NFT struct {
+	ChainId int64 `json:"chainId"`
+
+	// Collection Lowercase hex address
+	Collection      Address      `json:"collection"`
+	Metadata        *NFTMetadata `json:"metadata,omitempty"`
+	MintBlockNumber int64        `json:"mintBlockNumber"`
+	MintTimestamp   int64        `json:"mintTimestamp"`
+	MintTxHash      string       `json:"mintTxHash"`
+
+	// Owner Lowercase hex address
+	Owner  Address `json:"owner"`
+	Rarity *Rarity `json:"rarity,omitempty"`
+
+	// TokenId Decimal uint256
+	TokenId Uint256 `json:"tokenId"`
+	Uri     string  `json:"uri"`
+}
+
+// NFTMetadata defines model for NFTMetadata.
+type NFTMetadata struct {
+	// Attributes Attributes of the document as fetched
+	Attributes  interface{} `json:"attributes"`
+	Description string      `json:"description"`
+
+	// FetchedAt Unix timestamp
+	FetchedAt *int64            `json:"fetchedAt"`
+	Image     string            `json:"image"`
+	LastError string            `json:"lastError"`
+	Name      string            `json:"name"`
+	Status    NFTMetadataStatus `json:"status"`
+}
+
+// NFTMetadataStatus defines model for NFTMetadata.Status.
+type NFTMetadataStatus string
+
+// NFTPage defines model for NFTPage.
+type NFTPage struct {
+	Data []NFT `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// NFTResponse defines model for NFTResponse.
+type NFTResponse struct {
+	Data NFT `json:"data"`
+}
+
+// Rarity defines model for Rarity.
+type Rarity struct {
+	// Rank 1 is the rarest
+	Rank  int64   `json:"rank"`
+	Score float64 `json:"score"`
+}
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Status ReadinessStatus `json:"status"`
+}
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// RefreshAccepted defines model for RefreshAccepted.
+type RefreshAccepted struct {
+	Data struct {
+		Status RefreshAcceptedDataStatus `json:"status"`
+	} `json:"data"`
+}
+
+// RefreshAcceptedDataStatus defines model for RefreshAccepted.Data.Status.
+type RefreshAcceptedDataStatus string
+
+// SaleStats defines model for SaleStats.
+type SaleStats struct {
+	AveragePrice *string `json:"averagePrice"`
+
+	// Currency Lowercase hex address
+	Currency  Address `json:"currency"`
+	Floor     *string `json:"floor"`
+	SaleCount int     `json:"saleCount"`
+
+	// Volume Decimal uint256
+	Volume Uint256 `json:"volume"`
+}
+
+// StatsPoint defines model for StatsPoint.
+type StatsPoint struct {
+	// AveragePrice Null without sales
+	AveragePrice *string `json:"averagePrice"`
+
+	// Bucket Unix timestamp of the start of the hour or day
+	Bucket int64 `json:"bucket"`
+	Burns  int   `json:"burns"`
+	Buyers int   `json:"buyers"`
+
+	// Floor Null without sales
+	Floor *string `json:"floor"`
+
+	// Holders Null when it is not known
+	Holders   *int `json:"holders"`
+	Mints     int  `json:"mints"`
+	SaleCount int  `json:"saleCount"`
+	Sellers   int  `json:"sellers"`
+	Transfers int  `json:"transfers"`
+
+	// Volume Decimal uint256
+	Volume Uint256 `json:"volume"`
+}
+
+// StatsPointList defines model for StatsPointList.
+type StatsPointList struct {
+	Data []StatsPoint `json:"data"`
+}
+
+// StreamEvent Event of a transfer stream, the stream resumes after it with its cursor
+type StreamEvent struct {
+	// Block Last canonical block of a rollback, the transfers sent after it are no longer valid
+	Block    *int64          `json:"block,omitempty"`
+	Cursor   string          `json:"cursor"`
+	Transfer *Transfer       `json:"transfer,omitempty"`
+	Type     StreamEventType `json:"type"`
+}
+
+// StreamEventType defines model for StreamEvent.Type.
+type StreamEventType string
+
+// TraitCount defines model for TraitCount.
+type TraitCount struct {
+	Count     int64  `json:"count"`
+	TraitType string `json:"traitType"`
+	Value     string `json:"value"`
+}
+
+// TraitCountList defines model for TraitCountList.
+type TraitCountList struct {
+	Data []TraitCount `json:"data"`
+}
+
+// Transfer defines model for Transfer.
+type Transfer struct {
+	BlockNumber int64 `json:"blockNumber"`
+	ChainId     int64 `json:"chainId"`
+
+	// Collection Lowercase hex address
+	Collection Address             `json:"collection"`
+	Confidence *TransferConfidence `json:"confidence,omitempty"`
+
+	// Currency Lowercase hex address
+	Currency Address `json:"currency"`
+
+	// From Lowercase hex address
+	From     Address `json:"from"`
+	LogIndex int     `json:"logIndex"`
+
+	// Price Price inferred from the payments of the transaction, absent when none was found
+	Price       *string     `json:"price,omitempty"`
+	PriceSource *string     `json:"priceSource,omitempty"`
+	Tag         TransferTag `json:"tag"`
+
+	// Timestamp Unix timestamp of the block
+	Timestamp int64 `json:"timestamp"`
+
+	// To Lowercase hex address
+	To Address `json:"to"`
+
+	// TokenId Decimal uint256
+	TokenId Uint256 `json:"tokenId"`
+	TxHash  string  `json:"txHash"`
+}
+
+// TransferConfidence defines model for Transfer.Confidence.
+type TransferConfidence string
+
+// TransferTag defines model for Transfer.Tag.
+type TransferTag string
+
+// TransferPage defines model for TransferPage.
+type TransferPage struct {
+	Data []Transfer `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// Uint256 Decimal uint256
+type Uint256 = string
+
+// Webhook Outbound webhook, the missing filters match every transfer
+type Webhook struct {
+	Active bool `json:"active"`
+
+	// Address Lowercase hex address
+	Address *Address `json:"address,omitempty"`
+	ChainId int64    `json:"chainId"`
+
+	// Collection Lowercase hex address
+	Collection *Address `json:"collection,omitempty"`
+	CreatedAt  int64    `json:"createdAt"`
+	Id         int64    `json:"id"`
+
+	// Secret Only returned when the webhook is created
+	Secret *string     `json:"secret,omitempty"`
+	Tag    *WebhookTag `json:"tag,omitempty"`
+	Url    string      `json:"url"`
+}
+
+// WebhookTag defines model for Webhook.Tag.
+type WebhookTag string
+
+// WebhookAttempt defines model for WebhookAttempt.
+type WebhookAttempt struct {
+	AttemptedAt int64   `json:"attemptedAt"`
+	DurationMs  int64   `json:"durationMs"`
+	Error       *string `json:"error,omitempty"`
+
+	// StatusCode null when no response was received
+	StatusCode *int `json:"statusCode"`
+}
+
+// WebhookCreate defines model for WebhookCreate.
+type WebhookCreate struct {
+	// Address Lowercase hex address
+	Address *Address `json:"address,omitempty"`
+
+	// Collection Lowercase hex address
+	Collection *Address `json:"collection,omitempty"`
+
+	// Secret Key of the signatures, generated when missing
+	Secret *string           `json:"secret,omitempty"`
+	Tag    *WebhookCreateTag `json:"tag,omitempty"`
+
+	// Url Absolute http or https url receiving the deliveries
+	Url string `json:"url"`
+}
+
+// WebhookCreateTag defines model for WebhookCreate.Tag.
+type WebhookCreateTag string
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int                  `json:"attempts"`
+	CreatedAt   int64                `json:"createdAt"`
+	Event       WebhookDeliveryEvent `json:"event"`
+	History     []WebhookAttempt     `json:"history"`
+	Id          int64                `json:"id"`
+	NextAttempt int64                `json:"nextAttempt"`
+
+	// Payload Body of a delivery: an event of the transfer stream of the webhook, or a test
+	Payload   WebhookEvent          `json:"payload"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	WebhookId int64                 `json:"webhookId"`
+}
+
+// WebhookDeliveryEvent defines model for WebhookDelivery.Event.
+type WebhookDeliveryEvent string
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryPage defines model for WebhookDeliveryPage.
+type WebhookDeliveryPage struct {
+	Data []WebhookDelivery `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// WebhookDeliveryResponse defines model for WebhookDeliveryResponse.
+type WebhookDeliveryResponse struct {
+	Data WebhookDelivery `json:"data"`
+}
+
+// WebhookEvent Body of a delivery: an event of the transfer stream of the webhook, or a test
+type WebhookEvent struct {
+	// Block Last canonical block of a rollback, the transfers sent after it are no longer valid
+	Block     *int64           `json:"block,omitempty"`
+	Cursor    string           `json:"cursor"`
+	Transfer  *Transfer        `json:"transfer,omitempty"`
+	Type      WebhookEventType `json:"type"`
+	WebhookId int64            `json:"webhookId"`
+}
+
+// WebhookEventType defines model for WebhookEvent.Type.
+type WebhookEventType string
+
+// WebhookPage defines model for WebhookPage.
+type WebhookPage struct {
+	Data []Webhook `json:"data"`
+
+	// Next Cursor of the next page, null on the last one
+	Next *string `json:"next"`
+}
+
+// WebhookResponse defines model for WebhookResponse.
+type WebhookResponse struct {
+	// Data Outbound webhook, the missing filters match every transfer
+	Data Webhook `json:"data"`
+}
+
+// Addr defines model for Addr.
+type Addr = string
+
+// Chain defines model for Chain.
+type Chain = int64
+
+// Collection defines model for Collection.
+type Collection = string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// TokenId Decimal uint256
+type TokenId = Uint256
+
+// WebhookId defines model for WebhookId.
+type WebhookId = int64
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// InternalError defines model for InternalError.
+type InternalError = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// WebhookNotFound defines model for WebhookNotFound.
+type WebhookNotFound = Error
+
+// GetAddressHistoryParams defines parameters for GetAddressHistory.
+type GetAddressHistoryParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetAddressNftsParams defines parameters for GetAddressNfts.
+type GetAddressNftsParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetWebhooksParams defines parameters for GetWebhooks.
+type GetWebhooksParams struct {
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateWebhookParams defines parameters for CreateWebhook.
+type CreateWebhookParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetCollectionHistoryParams defines parameters for GetCollectionHistory.
+type GetCollectionHistoryParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetCollectionStatsParams defines parameters for GetCollectionStats.
+type GetCollectionStatsParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain  *Chain                          `form:"chain,omitempty" json:"chain,omitempty"`
+	Window *GetCollectionStatsParamsWindow `form:"window,omitempty" json:"window,omitempty"`
+}
+
+// GetCollectionStatsParamsWindow defines parameters for GetCollectionStats.
+type GetCollectionStatsParamsWindow string
+
+// GetCollectionStatsSeriesParams defines parameters for GetCollectionStatsSeries.
+type GetCollectionStatsSeriesParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain  *Chain                                `form:"chain,omitempty" json:"chain,omitempty"`
+	Period *GetCollectionStatsSeriesParamsPeriod `form:"period,omitempty" json:"period,omitempty"`
+
+	// From Unix timestamp, 30 periods before to by default
+	From *int64 `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Unix timestamp, now by default
+	To *int64 `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetCollectionStatsSeriesParamsPeriod defines parameters for GetCollectionStatsSeries.
+type GetCollectionStatsSeriesParamsPeriod string
+
+// GetCollectionTraitsParams defines parameters for GetCollectionTraits.
+type GetCollectionTraitsParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetCollectionNftsParams defines parameters for GetCollectionNfts.
+type GetCollectionNftsParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor                      `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Sort   *GetCollectionNftsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Trait trait[<type>]=<value> keeps the NFTs having the trait, repeated values of a type match any of them
+	Trait *map[string]string `json:"trait,omitempty"`
+}
+
+// GetCollectionNftsParamsSort defines parameters for GetCollectionNfts.
+type GetCollectionNftsParamsSort string
+
+// GetERC1155AddressHistoryParams defines parameters for GetERC1155AddressHistory.
+type GetERC1155AddressHistoryParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetERC1155AddressBalancesParams defines parameters for GetERC1155AddressBalances.
+type GetERC1155AddressBalancesParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetERC1155CollectionHistoryParams defines parameters for GetERC1155CollectionHistory.
+type GetERC1155CollectionHistoryParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetERC1155CollectionStatsParams defines parameters for GetERC1155CollectionStats.
+type GetERC1155CollectionStatsParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetERC1155CollectionTokensParams defines parameters for GetERC1155CollectionTokens.
+type GetERC1155CollectionTokensParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetERC1155HistoryParams defines parameters for GetERC1155History.
+type GetERC1155HistoryParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetERC1155DataParams defines parameters for GetERC1155Data.
+type GetERC1155DataParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetNftHistoryParams defines parameters for GetNftHistory.
+type GetNftHistoryParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Limit Rows of the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next of the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// RefreshNftMetadataParams defines parameters for RefreshNftMetadata.
+type RefreshNftMetadataParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetNftDataParams defines parameters for GetNftData.
+type GetNftDataParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// StreamTransfersParams defines parameters for StreamTransfers.
+type StreamTransfersParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Collection Transfers of a collection, lowercase, uppercase or EIP-55 checksummed
+	Collection *string `form:"collection,omitempty" json:"collection,omitempty"`
+
+	// TokenId Transfers of a token of the collection
+	TokenId *Uint256 `form:"tokenId,omitempty" json:"tokenId,omitempty"`
+
+	// Address Transfers from or to an address, lowercase, uppercase or EIP-55 checksummed
+	Address *string                   `form:"address,omitempty" json:"address,omitempty"`
+	Tag     *StreamTransfersParamsTag `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Cursor Cursor of the last received event, the stream starts at the last indexed block without it
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// LastEventID Cursor of the last received event, sent by EventSource on reconnection, it takes precedence over the cursor parameter
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// StreamTransfersParamsTag defines parameters for StreamTransfers.
+type StreamTransfersParamsTag string
+
+// StreamTransfersWebSocketParams defines parameters for StreamTransfersWebSocket.
+type StreamTransfersWebSocketParams struct {
+	// Chain Chain id, default_chain_id of the API without it
+	Chain *Chain `form:"chain,omitempty" json:"chain,omitempty"`
+
+	// Collection Transfers of a collection, lowercase, uppercase or EIP-55 checksummed
+	Collection *string `form:"collection,omitempty" json:"collection,omitempty"`
+
+	// TokenId Transfers of a token of the collection
+	TokenId *Uint256 `form:"tokenId,omitempty" json:"tokenId,omitempty"`
+
+	// Address Transfers from or to an address, lowercase, uppercase or EIP-55 checksummed
+	Address *string                            `form:"address,omitempty" json:"address,omitempty"`
+	Tag     *StreamTransfersWebSocketParamsTag `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Cursor Cursor of the last received event, the stream starts at the last indexed block without it
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// StreamTransfersWebSocketParamsTag defines parameters for StreamTransfersWebSocket.
+type StreamTransfersWebSocketParamsTag string
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = WebhookCreate
+
+// PostGraphQLJSONRequestBody defines body for PostGraphQL for application/json ContentType.
+type PostGraphQLJSONRequestBody = GraphQLRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAddressHistory request
+	GetAddressHistory(ctx context.Context, addr Addr, params *GetAddressHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAddressNfts request
+	GetAddressNfts(ctx context.Context, addr Addr, params *GetAddressNftsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooks request
+	GetWebhooks(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateWebhookWithBody request with any body
+	CreateWebhookWithBody(ctx context.Context, params *CreateWebhookParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateWebhook(ctx context.Context, params *CreateWebhookParams, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhook request
+	GetWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhookDeliveries request
+	GetWebhookDeliveries(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DisableWebhook request
+	DisableWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TestWebhook request
+	TestWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollectionHistory request
+	GetCollectionHistory(ctx context.Context, addr Addr, params *GetCollectionHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollectionStats request
+	GetCollectionStats(ctx context.Context, addr Addr, params *GetCollectionStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollectionStatsSeries request
+	GetCollectionStatsSeries(ctx context.Context, addr Addr, params *GetCollectionStatsSeriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollectionTraits request
+	GetCollectionTraits(ctx context.Context, addr Addr, params *GetCollectionTraitsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollectionNfts request
+	GetCollectionNfts(ctx context.Context, addr Addr, params *GetCollectionNftsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155AddressHistory request
+	GetERC1155AddressHistory(ctx context.Context, addr Addr, params *GetERC1155AddressHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155AddressBalances request
+	GetERC1155AddressBalances(ctx context.Context, addr Addr, params *GetERC1155AddressBalancesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155CollectionHistory request
+	GetERC1155CollectionHistory(ctx context.Context, addr Addr, params *GetERC1155CollectionHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155CollectionStats request
+	GetERC1155CollectionStats(ctx context.Context, addr Addr, params *GetERC1155CollectionStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155CollectionTokens request
+	GetERC1155CollectionTokens(ctx context.Context, addr Addr, params *GetERC1155CollectionTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155History request
+	GetERC1155History(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155HistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetERC1155Data request
+	GetERC1155Data(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155DataParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostGraphQLWithBody request with any body
+	PostGraphQLWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostGraphQL(ctx context.Context, body PostGraphQLJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNftHistory request
+	GetNftHistory(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefreshNftMetadata request
+	RefreshNftMetadata(ctx context.Context, collection Collection, tokenId TokenId, params *RefreshNftMetadataParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNftData request
+	GetNftData(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftDataParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadiness request
+	GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamTransfers request
+	StreamTransfers(ctx context.Context, params *StreamTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamTransfersWebSocket request
+	StreamTransfersWebSocket(ctx context.Context, params *StreamTransfersWebSocketParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAddressHistory(ctx context.Context, addr Addr, params *GetAddressHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAddressHistoryRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAddressNfts(ctx context.Context, addr Addr, params *GetAddressNftsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAddressNftsRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooks(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWebhookWithBody(ctx context.Context, params *CreateWebhookParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWebhookRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, params *CreateWebhookParams, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWebhookRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhookRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhookDeliveries(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhookDeliveriesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DisableWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDisableWebhookRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TestWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTestWebhookRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCollectionHistory(ctx context.Context, addr Addr, params *GetCollectionHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionHistoryRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCollectionStats(ctx context.Context, addr Addr, params *GetCollectionStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionStatsRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCollectionStatsSeries(ctx context.Context, addr Addr, params *GetCollectionStatsSeriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionStatsSeriesRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCollectionTraits(ctx context.Context, addr Addr, params *GetCollectionTraitsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionTraitsRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCollectionNfts(ctx context.Context, addr Addr, params *GetCollectionNftsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionNftsRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155AddressHistory(ctx context.Context, addr Addr, params *GetERC1155AddressHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155AddressHistoryRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155AddressBalances(ctx context.Context, addr Addr, params *GetERC1155AddressBalancesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155AddressBalancesRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155CollectionHistory(ctx context.Context, addr Addr, params *GetERC1155CollectionHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155CollectionHistoryRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155CollectionStats(ctx context.Context, addr Addr, params *GetERC1155CollectionStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155CollectionStatsRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155CollectionTokens(ctx context.Context, addr Addr, params *GetERC1155CollectionTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155CollectionTokensRequest(c.Server, addr, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155History(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155HistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155HistoryRequest(c.Server, collection, tokenId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetERC1155Data(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155DataParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetERC1155DataRequest(c.Server, collection, tokenId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostGraphQLWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGraphQLRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostGraphQL(ctx context.Context, body PostGraphQLJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGraphQLRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNftHistory(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNftHistoryRequest(c.Server, collection, tokenId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RefreshNftMetadata(ctx context.Context, collection Collection, tokenId TokenId, params *RefreshNftMetadataParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshNftMetadataRequest(c.Server, collection, tokenId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNftData(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftDataParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNftDataRequest(c.Server, collection, tokenId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamTransfers(ctx context.Context, params *StreamTransfersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamTransfersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamTransfersWebSocket(ctx context.Context, params *StreamTransfersWebSocketParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamTransfersWebSocketRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAddressHistoryRequest generates requests for GetAddressHistory
+func NewGetAddressHistoryRequest(server string, addr Addr, params *GetAddressHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/address/history/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAddressNftsRequest generates requests for GetAddressNfts
+func NewGetAddressNftsRequest(server string, addr Addr, params *GetAddressNftsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/address/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhooksRequest generates requests for GetWebhooks
+func NewGetWebhooksRequest(server string, params *GetWebhooksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateWebhookRequest calls the generic CreateWebhook builder with application/json body
+func NewCreateWebhookRequest(server string, params *CreateWebhookParams, body CreateWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateWebhookRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateWebhookRequestWithBody generates requests for CreateWebhook with any type of body
+func NewCreateWebhookRequestWithBody(server string, params *CreateWebhookParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWebhookRequest generates requests for GetWebhook
+func NewGetWebhookRequest(server string, id WebhookId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhookDeliveriesRequest generates requests for GetWebhookDeliveries
+func NewGetWebhookDeliveriesRequest(server string, id WebhookId, params *GetWebhookDeliveriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDisableWebhookRequest generates requests for DisableWebhook
+func NewDisableWebhookRequest(server string, id WebhookId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/%s/disable", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTestWebhookRequest generates requests for TestWebhook
+func NewTestWebhookRequest(server string, id WebhookId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/%s/test", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionHistoryRequest generates requests for GetCollectionHistory
+func NewGetCollectionHistoryRequest(server string, addr Addr, params *GetCollectionHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collection/history/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionStatsRequest generates requests for GetCollectionStats
+func NewGetCollectionStatsRequest(server string, addr Addr, params *GetCollectionStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collection/stats/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Window != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "window", runtime.ParamLocationQuery, *params.Window); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionStatsSeriesRequest generates requests for GetCollectionStatsSeries
+func NewGetCollectionStatsSeriesRequest(server string, addr Addr, params *GetCollectionStatsSeriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collection/stats/%s/series", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Period != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "period", runtime.ParamLocationQuery, *params.Period); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionTraitsRequest generates requests for GetCollectionTraits
+func NewGetCollectionTraitsRequest(server string, addr Addr, params *GetCollectionTraitsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collection/traits/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionNftsRequest generates requests for GetCollectionNfts
+func NewGetCollectionNftsRequest(server string, addr Addr, params *GetCollectionNftsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collection/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Trait != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("deepObject", true, "trait", runtime.ParamLocationQuery, *params.Trait); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155AddressHistoryRequest generates requests for GetERC1155AddressHistory
+func NewGetERC1155AddressHistoryRequest(server string, addr Addr, params *GetERC1155AddressHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/address/history/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155AddressBalancesRequest generates requests for GetERC1155AddressBalances
+func NewGetERC1155AddressBalancesRequest(server string, addr Addr, params *GetERC1155AddressBalancesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/address/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155CollectionHistoryRequest generates requests for GetERC1155CollectionHistory
+func NewGetERC1155CollectionHistoryRequest(server string, addr Addr, params *GetERC1155CollectionHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/collection/history/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155CollectionStatsRequest generates requests for GetERC1155CollectionStats
+func NewGetERC1155CollectionStatsRequest(server string, addr Addr, params *GetERC1155CollectionStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/collection/stats/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155CollectionTokensRequest generates requests for GetERC1155CollectionTokens
+func NewGetERC1155CollectionTokensRequest(server string, addr Addr, params *GetERC1155CollectionTokensParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "addr", runtime.ParamLocationPath, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/collection/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155HistoryRequest generates requests for GetERC1155History
+func NewGetERC1155HistoryRequest(server string, collection Collection, tokenId TokenId, params *GetERC1155HistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "tokenId", runtime.ParamLocationPath, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/nft/history/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetERC1155DataRequest generates requests for GetERC1155Data
+func NewGetERC1155DataRequest(server string, collection Collection, tokenId TokenId, params *GetERC1155DataParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "tokenId", runtime.ParamLocationPath, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erc1155/nft/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostGraphQLRequest calls the generic PostGraphQL builder with application/json body
+func NewPostGraphQLRequest(server string, body PostGraphQLJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostGraphQLRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostGraphQLRequestWithBody generates requests for PostGraphQL with any type of body
+func NewPostGraphQLRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/graphql")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNftHistoryRequest generates requests for GetNftHistory
+func NewGetNftHistoryRequest(server string, collection Collection, tokenId TokenId, params *GetNftHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "tokenId", runtime.ParamLocationPath, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nft/history/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRefreshNftMetadataRequest generates requests for RefreshNftMetadata
+func NewRefreshNftMetadataRequest(server string, collection Collection, tokenId TokenId, params *RefreshNftMetadataParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "tokenId", runtime.ParamLocationPath, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nft/refresh/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNftDataRequest generates requests for GetNftData
+func NewGetNftDataRequest(server string, collection Collection, tokenId TokenId, params *GetNftDataParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "tokenId", runtime.ParamLocationPath, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nft/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReadinessRequest generates requests for GetReadiness
+func NewGetReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamTransfersRequest generates requests for StreamTransfers
+func NewStreamTransfersRequest(server string, params *StreamTransfersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stream/transfers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Collection != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "collection", runtime.ParamLocationQuery, *params.Collection); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TokenId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tokenId", runtime.ParamLocationQuery, *params.TokenId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Address != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "address", runtime.ParamLocationQuery, *params.Address); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewStreamTransfersWebSocketRequest generates requests for StreamTransfersWebSocket
+func NewStreamTransfersWebSocketRequest(server string, params *StreamTransfersWebSocketParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stream/transfers/ws")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Collection != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "collection", runtime.ParamLocationQuery, *params.Collection); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TokenId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tokenId", runtime.ParamLocationQuery, *params.TokenId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Address != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "address", runtime.ParamLocationQuery, *params.Address); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAddressHistoryWithResponse request
+	GetAddressHistoryWithResponse(ctx context.Context, addr Addr, params *GetAddressHistoryParams, reqEditors ...RequestEditorFn) (*GetAddressHistoryResponse, error)
+
+	// GetAddressNftsWithResponse request
+	GetAddressNftsWithResponse(ctx context.Context, addr Addr, params *GetAddressNftsParams, reqEditors ...RequestEditorFn) (*GetAddressNftsResponse, error)
+
+	// GetWebhooksWithResponse request
+	GetWebhooksWithResponse(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error)
+
+	// CreateWebhookWithBodyWithResponse request with any body
+	CreateWebhookWithBodyWithResponse(ctx context.Context, params *CreateWebhookParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error)
+
+	CreateWebhookWithResponse(ctx context.Context, params *CreateWebhookParams, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error)
+
+	// GetWebhookWithResponse request
+	GetWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*GetWebhookResponse, error)
+
+	// GetWebhookDeliveriesWithResponse request
+	GetWebhookDeliveriesWithResponse(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhookDeliveriesResponse, error)
+
+	// DisableWebhookWithResponse request
+	DisableWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*DisableWebhookResponse, error)
+
+	// TestWebhookWithResponse request
+	TestWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*TestWebhookResponse, error)
+
+	// GetCollectionHistoryWithResponse request
+	GetCollectionHistoryWithResponse(ctx context.Context, addr Addr, params *GetCollectionHistoryParams, reqEditors ...RequestEditorFn) (*GetCollectionHistoryResponse, error)
+
+	// GetCollectionStatsWithResponse request
+	GetCollectionStatsWithResponse(ctx context.Context, addr Addr, params *GetCollectionStatsParams, reqEditors ...RequestEditorFn) (*GetCollectionStatsResponse, error)
+
+	// GetCollectionStatsSeriesWithResponse request
+	GetCollectionStatsSeriesWithResponse(ctx context.Context, addr Addr, params *GetCollectionStatsSeriesParams, reqEditors ...RequestEditorFn) (*GetCollectionStatsSeriesResponse, error)
+
+	// GetCollectionTraitsWithResponse request
+	GetCollectionTraitsWithResponse(ctx context.Context, addr Addr, params *GetCollectionTraitsParams, reqEditors ...RequestEditorFn) (*GetCollectionTraitsResponse, error)
+
+	// GetCollectionNftsWithResponse request
+	GetCollectionNftsWithResponse(ctx context.Context, addr Addr, params *GetCollectionNftsParams, reqEditors ...RequestEditorFn) (*GetCollectionNftsResponse, error)
+
+	// GetERC1155AddressHistoryWithResponse request
+	GetERC1155AddressHistoryWithResponse(ctx context.Context, addr Addr, params *GetERC1155AddressHistoryParams, reqEditors ...RequestEditorFn) (*GetERC1155AddressHistoryResponse, error)
+
+	// GetERC1155AddressBalancesWithResponse request
+	GetERC1155AddressBalancesWithResponse(ctx context.Context, addr Addr, params *GetERC1155AddressBalancesParams, reqEditors ...RequestEditorFn) (*GetERC1155AddressBalancesResponse, error)
+
+	// GetERC1155CollectionHistoryWithResponse request
+	GetERC1155CollectionHistoryWithResponse(ctx context.Context, addr Addr, params *GetERC1155CollectionHistoryParams, reqEditors ...RequestEditorFn) (*GetERC1155CollectionHistoryResponse, error)
+
+	// GetERC1155CollectionStatsWithResponse request
+	GetERC1155CollectionStatsWithResponse(ctx context.Context, addr Addr, params *GetERC1155CollectionStatsParams, reqEditors ...RequestEditorFn) (*GetERC1155CollectionStatsResponse, error)
+
+	// GetERC1155CollectionTokensWithResponse request
+	GetERC1155CollectionTokensWithResponse(ctx context.Context, addr Addr, params *GetERC1155CollectionTokensParams, reqEditors ...RequestEditorFn) (*GetERC1155CollectionTokensResponse, error)
+
+	// GetERC1155HistoryWithResponse request
+	GetERC1155HistoryWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155HistoryParams, reqEditors ...RequestEditorFn) (*GetERC1155HistoryResponse, error)
+
+	// GetERC1155DataWithResponse request
+	GetERC1155DataWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155DataParams, reqEditors ...RequestEditorFn) (*GetERC1155DataResponse, error)
+
+	// PostGraphQLWithBodyWithResponse request with any body
+	PostGraphQLWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostGraphQLResponse, error)
+
+	PostGraphQLWithResponse(ctx context.Context, body PostGraphQLJSONRequestBody, reqEditors ...RequestEditorFn) (*PostGraphQLResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetNftHistoryWithResponse request
+	GetNftHistoryWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftHistoryParams, reqEditors ...RequestEditorFn) (*GetNftHistoryResponse, error)
+
+	// RefreshNftMetadataWithResponse request
+	RefreshNftMetadataWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *RefreshNftMetadataParams, reqEditors ...RequestEditorFn) (*RefreshNftMetadataResponse, error)
+
+	// GetNftDataWithResponse request
+	GetNftDataWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftDataParams, reqEditors ...RequestEditorFn) (*GetNftDataResponse, error)
+
+	// GetReadinessWithResponse request
+	GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error)
+
+	// StreamTransfersWithResponse request
+	StreamTransfersWithResponse(ctx context.Context, params *StreamTransfersParams, reqEditors ...RequestEditorFn) (*StreamTransfersResponse, error)
+
+	// StreamTransfersWebSocketWithResponse request
+	StreamTransfersWebSocketWithResponse(ctx context.Context, params *StreamTransfersWebSocketParams, reqEditors ...RequestEditorFn) (*StreamTransfersWebSocketResponse, error)
+}
+
+type GetAddressHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransferPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAddressHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAddressHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAddressNftsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NFTPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAddressNftsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAddressNftsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookPage
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *WebhookResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *WebhookNotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookDeliveryPage
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *WebhookNotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhookDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhookDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DisableWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *WebhookNotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DisableWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DisableWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TestWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookDeliveryResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *WebhookNotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r TestWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TestWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransferPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CollectionWindowResponse
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionStatsSeriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StatsPointList
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionStatsSeriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionStatsSeriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionTraitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TraitCountList
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionTraitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionTraitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionNftsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NFTPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionNftsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionNftsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155AddressHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155TransferPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155AddressHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155AddressHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155AddressBalancesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155BalancePage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155AddressBalancesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155AddressBalancesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155CollectionHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155TransferPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155CollectionHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155CollectionHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155CollectionStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155CollectionStatsResponse
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155CollectionStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155CollectionStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155CollectionTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155TokenPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155CollectionTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155CollectionTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155HistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155TransferPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155HistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155HistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetERC1155DataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ERC1155TokenResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetERC1155DataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetERC1155DataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostGraphQLResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GraphQLResponse
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r PostGraphQLResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostGraphQLResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNftHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransferPage
+	JSON400      *BadRequest
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNftHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNftHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RefreshNftMetadataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *RefreshAccepted
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r RefreshNftMetadataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefreshNftMetadataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNftDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NFTResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNftDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNftDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Readiness
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON500      *InternalError
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamTransfersWebSocketResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON500      *InternalError
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamTransfersWebSocketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamTransfersWebSocketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAddressHistoryWithResponse request returning *GetAddressHistoryResponse
+func (c *ClientWithResponses) GetAddressHistoryWithResponse(ctx context.Context, addr Addr, params *GetAddressHistoryParams, reqEditors ...RequestEditorFn) (*GetAddressHistoryResponse, error) {
+	rsp, err := c.GetAddressHistory(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAddressHistoryResponse(rsp)
+}
+
+// GetAddressNftsWithResponse request returning *GetAddressNftsResponse
+func (c *ClientWithResponses) GetAddressNftsWithResponse(ctx context.Context, addr Addr, params *GetAddressNftsParams, reqEditors ...RequestEditorFn) (*GetAddressNftsResponse, error) {
+	rsp, err := c.GetAddressNfts(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAddressNftsResponse(rsp)
+}
+
+// GetWebhooksWithResponse request returning *GetWebhooksResponse
+func (c *ClientWithResponses) GetWebhooksWithResponse(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error) {
+	rsp, err := c.GetWebhooks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksResponse(rsp)
+}
+
+// CreateWebhookWithBodyWithResponse request with arbitrary body returning *CreateWebhookResponse
+func (c *ClientWithResponses) CreateWebhookWithBodyWithResponse(ctx context.Context, params *CreateWebhookParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error) {
+	rsp, err := c.CreateWebhookWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateWebhookWithResponse(ctx context.Context, params *CreateWebhookParams, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error) {
+	rsp, err := c.CreateWebhook(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWebhookResponse(rsp)
+}
+
+// GetWebhookWithResponse request returning *GetWebhookResponse
+func (c *ClientWithResponses) GetWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*GetWebhookResponse, error) {
+	rsp, err := c.GetWebhook(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhookResponse(rsp)
+}
+
+// GetWebhookDeliveriesWithResponse request returning *GetWebhookDeliveriesResponse
+func (c *ClientWithResponses) GetWebhookDeliveriesWithResponse(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhookDeliveriesResponse, error) {
+	rsp, err := c.GetWebhookDeliveries(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhookDeliveriesResponse(rsp)
+}
+
+// DisableWebhookWithResponse request returning *DisableWebhookResponse
+func (c *ClientWithResponses) DisableWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*DisableWebhookResponse, error) {
+	rsp, err := c.DisableWebhook(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDisableWebhookResponse(rsp)
+}
+
+// TestWebhookWithResponse request returning *TestWebhookResponse
+func (c *ClientWithResponses) TestWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*TestWebhookResponse, error) {
+	rsp, err := c.TestWebhook(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTestWebhookResponse(rsp)
+}
+
+// GetCollectionHistoryWithResponse request returning *GetCollectionHistoryResponse
+func (c *ClientWithResponses) GetCollectionHistoryWithResponse(ctx context.Context, addr Addr, params *GetCollectionHistoryParams, reqEditors ...RequestEditorFn) (*GetCollectionHistoryResponse, error) {
+	rsp, err := c.GetCollectionHistory(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionHistoryResponse(rsp)
+}
+
+// GetCollectionStatsWithResponse request returning *GetCollectionStatsResponse
+func (c *ClientWithResponses) GetCollectionStatsWithResponse(ctx context.Context, addr Addr, params *GetCollectionStatsParams, reqEditors ...RequestEditorFn) (*GetCollectionStatsResponse, error) {
+	rsp, err := c.GetCollectionStats(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionStatsResponse(rsp)
+}
+
+// GetCollectionStatsSeriesWithResponse request returning *GetCollectionStatsSeriesResponse
+func (c *ClientWithResponses) GetCollectionStatsSeriesWithResponse(ctx context.Context, addr Addr, params *GetCollectionStatsSeriesParams, reqEditors ...RequestEditorFn) (*GetCollectionStatsSeriesResponse, error) {
+	rsp, err := c.GetCollectionStatsSeries(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionStatsSeriesResponse(rsp)
+}
+
+// GetCollectionTraitsWithResponse request returning *GetCollectionTraitsResponse
+func (c *ClientWithResponses) GetCollectionTraitsWithResponse(ctx context.Context, addr Addr, params *GetCollectionTraitsParams, reqEditors ...RequestEditorFn) (*GetCollectionTraitsResponse, error) {
+	rsp, err := c.GetCollectionTraits(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionTraitsResponse(rsp)
+}
+
+// GetCollectionNftsWithResponse request returning *GetCollectionNftsResponse
+func (c *ClientWithResponses) GetCollectionNftsWithResponse(ctx context.Context, addr Addr, params *GetCollectionNftsParams, reqEditors ...RequestEditorFn) (*GetCollectionNftsResponse, error) {
+	rsp, err := c.GetCollectionNfts(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionNftsResponse(rsp)
+}
+
+// GetERC1155AddressHistoryWithResponse request returning *GetERC1155AddressHistoryResponse
+func (c *ClientWithResponses) GetERC1155AddressHistoryWithResponse(ctx context.Context, addr Addr, params *GetERC1155AddressHistoryParams, reqEditors ...RequestEditorFn) (*GetERC1155AddressHistoryResponse, error) {
+	rsp, err := c.GetERC1155AddressHistory(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155AddressHistoryResponse(rsp)
+}
+
+// GetERC1155AddressBalancesWithResponse request returning *GetERC1155AddressBalancesResponse
+func (c *ClientWithResponses) GetERC1155AddressBalancesWithResponse(ctx context.Context, addr Addr, params *GetERC1155AddressBalancesParams, reqEditors ...RequestEditorFn) (*GetERC1155AddressBalancesResponse, error) {
+	rsp, err := c.GetERC1155AddressBalances(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155AddressBalancesResponse(rsp)
+}
+
+// GetERC1155CollectionHistoryWithResponse request returning *GetERC1155CollectionHistoryResponse
+func (c *ClientWithResponses) GetERC1155CollectionHistoryWithResponse(ctx context.Context, addr Addr, params *GetERC1155CollectionHistoryParams, reqEditors ...RequestEditorFn) (*GetERC1155CollectionHistoryResponse, error) {
+	rsp, err := c.GetERC1155CollectionHistory(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155CollectionHistoryResponse(rsp)
+}
+
+// GetERC1155CollectionStatsWithResponse request returning *GetERC1155CollectionStatsResponse
+func (c *ClientWithResponses) GetERC1155CollectionStatsWithResponse(ctx context.Context, addr Addr, params *GetERC1155CollectionStatsParams, reqEditors ...RequestEditorFn) (*GetERC1155CollectionStatsResponse, error) {
+	rsp, err := c.GetERC1155CollectionStats(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155CollectionStatsResponse(rsp)
+}
+
+// GetERC1155CollectionTokensWithResponse request returning *GetERC1155CollectionTokensResponse
+func (c *ClientWithResponses) GetERC1155CollectionTokensWithResponse(ctx context.Context, addr Addr, params *GetERC1155CollectionTokensParams, reqEditors ...RequestEditorFn) (*GetERC1155CollectionTokensResponse, error) {
+	rsp, err := c.GetERC1155CollectionTokens(ctx, addr, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155CollectionTokensResponse(rsp)
+}
+
+// GetERC1155HistoryWithResponse request returning *GetERC1155HistoryResponse
+func (c *ClientWithResponses) GetERC1155HistoryWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155HistoryParams, reqEditors ...RequestEditorFn) (*GetERC1155HistoryResponse, error) {
+	rsp, err := c.GetERC1155History(ctx, collection, tokenId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155HistoryResponse(rsp)
+}
+
+// GetERC1155DataWithResponse request returning *GetERC1155DataResponse
+func (c *ClientWithResponses) GetERC1155DataWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetERC1155DataParams, reqEditors ...RequestEditorFn) (*GetERC1155DataResponse, error) {
+	rsp, err := c.GetERC1155Data(ctx, collection, tokenId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetERC1155DataResponse(rsp)
+}
+
+// PostGraphQLWithBodyWithResponse request with arbitrary body returning *PostGraphQLResponse
+func (c *ClientWithResponses) PostGraphQLWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostGraphQLResponse, error) {
+	rsp, err := c.PostGraphQLWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGraphQLResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostGraphQLWithResponse(ctx context.Context, body PostGraphQLJSONRequestBody, reqEditors ...RequestEditorFn) (*PostGraphQLResponse, error) {
+	rsp, err := c.PostGraphQL(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGraphQLResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetNftHistoryWithResponse request returning *GetNftHistoryResponse
+func (c *ClientWithResponses) GetNftHistoryWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftHistoryParams, reqEditors ...RequestEditorFn) (*GetNftHistoryResponse, error) {
+	rsp, err := c.GetNftHistory(ctx, collection, tokenId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNftHistoryResponse(rsp)
+}
+
+// RefreshNftMetadataWithResponse request returning *RefreshNftMetadataResponse
+func (c *ClientWithResponses) RefreshNftMetadataWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *RefreshNftMetadataParams, reqEditors ...RequestEditorFn) (*RefreshNftMetadataResponse, error) {
+	rsp, err := c.RefreshNftMetadata(ctx, collection, tokenId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshNftMetadataResponse(rsp)
+}
+
+// GetNftDataWithResponse request returning *GetNftDataResponse
+func (c *ClientWithResponses) GetNftDataWithResponse(ctx context.Context, collection Collection, tokenId TokenId, params *GetNftDataParams, reqEditors ...RequestEditorFn) (*GetNftDataResponse, error) {
+	rsp, err := c.GetNftData(ctx, collection, tokenId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNftDataResponse(rsp)
+}
+
+// GetReadinessWithResponse request returning *GetReadinessResponse
+func (c *ClientWithResponses) GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error) {
+	rsp, err := c.GetReadiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadinessResponse(rsp)
+}
+
+// StreamTransfersWithResponse request returning *StreamTransfersResponse
+func (c *ClientWithResponses) StreamTransfersWithResponse(ctx context.Context, params *StreamTransfersParams, reqEditors ...RequestEditorFn) (*StreamTransfersResponse, error) {
+	rsp, err := c.StreamTransfers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamTransfersResponse(rsp)
+}
+
+// StreamTransfersWebSocketWithResponse request returning *StreamTransfersWebSocketResponse
+func (c *ClientWithResponses) StreamTransfersWebSocketWithResponse(ctx context.Context, params *StreamTransfersWebSocketParams, reqEditors ...RequestEditorFn) (*StreamTransfersWebSocketResponse, error) {
+	rsp, err := c.StreamTransfersWebSocket(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamTransfersWebSocketResponse(rsp)
+}
+
+// ParseGetAddressHistoryResponse parses an HTTP response from a GetAddressHistoryWithResponse call
+func ParseGetAddressHistoryResponse(rsp *http.Response) (*GetAddressHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAddressHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransferPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAddressNftsResponse parses an HTTP response from a GetAddressNftsWithResponse call
+func ParseGetAddressNftsResponse(rsp *http.Response) (*GetAddressNftsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAddressNftsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NFTPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhooksResponse parses an HTTP response from a GetWebhooksWithResponse call
+func ParseGetWebhooksResponse(rsp *http.Response) (*GetWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateWebhookResponse parses an HTTP response from a CreateWebhookWithResponse call
+func ParseCreateWebhookResponse(rsp *http.Response) (*CreateWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest WebhookResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhookResponse parses an HTTP response from a GetWebhookWithResponse call
+func ParseGetWebhookResponse(rsp *http.Response) (*GetWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest WebhookNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhookDeliveriesResponse parses an HTTP response from a GetWebhookDeliveriesWithResponse call
+func ParseGetWebhookDeliveriesResponse(rsp *http.Response) (*GetWebhookDeliveriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhookDeliveriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookDeliveryPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest WebhookNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDisableWebhookResponse parses an HTTP response from a DisableWebhookWithResponse call
+func ParseDisableWebhookResponse(rsp *http.Response) (*DisableWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DisableWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest WebhookNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTestWebhookResponse parses an HTTP response from a TestWebhookWithResponse call
+func ParseTestWebhookResponse(rsp *http.Response) (*TestWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TestWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookDeliveryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest WebhookNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionHistoryResponse parses an HTTP response from a GetCollectionHistoryWithResponse call
+func ParseGetCollectionHistoryResponse(rsp *http.Response) (*GetCollectionHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransferPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionStatsResponse parses an HTTP response from a GetCollectionStatsWithResponse call
+func ParseGetCollectionStatsResponse(rsp *http.Response) (*GetCollectionStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CollectionWindowResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionStatsSeriesResponse parses an HTTP response from a GetCollectionStatsSeriesWithResponse call
+func ParseGetCollectionStatsSeriesResponse(rsp *http.Response) (*GetCollectionStatsSeriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionStatsSeriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StatsPointList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionTraitsResponse parses an HTTP response from a GetCollectionTraitsWithResponse call
+func ParseGetCollectionTraitsResponse(rsp *http.Response) (*GetCollectionTraitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionTraitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TraitCountList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionNftsResponse parses an HTTP response from a GetCollectionNftsWithResponse call
+func ParseGetCollectionNftsResponse(rsp *http.Response) (*GetCollectionNftsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionNftsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NFTPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155AddressHistoryResponse parses an HTTP response from a GetERC1155AddressHistoryWithResponse call
+func ParseGetERC1155AddressHistoryResponse(rsp *http.Response) (*GetERC1155AddressHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155AddressHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155TransferPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155AddressBalancesResponse parses an HTTP response from a GetERC1155AddressBalancesWithResponse call
+func ParseGetERC1155AddressBalancesResponse(rsp *http.Response) (*GetERC1155AddressBalancesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155AddressBalancesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155BalancePage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155CollectionHistoryResponse parses an HTTP response from a GetERC1155CollectionHistoryWithResponse call
+func ParseGetERC1155CollectionHistoryResponse(rsp *http.Response) (*GetERC1155CollectionHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155CollectionHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155TransferPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155CollectionStatsResponse parses an HTTP response from a GetERC1155CollectionStatsWithResponse call
+func ParseGetERC1155CollectionStatsResponse(rsp *http.Response) (*GetERC1155CollectionStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155CollectionStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155CollectionStatsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155CollectionTokensResponse parses an HTTP response from a GetERC1155CollectionTokensWithResponse call
+func ParseGetERC1155CollectionTokensResponse(rsp *http.Response) (*GetERC1155CollectionTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155CollectionTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155TokenPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155HistoryResponse parses an HTTP response from a GetERC1155HistoryWithResponse call
+func ParseGetERC1155HistoryResponse(rsp *http.Response) (*GetERC1155HistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155HistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155TransferPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetERC1155DataResponse parses an HTTP response from a GetERC1155DataWithResponse call
+func ParseGetERC1155DataResponse(rsp *http.Response) (*GetERC1155DataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetERC1155DataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ERC1155TokenResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostGraphQLResponse parses an HTTP response from a PostGraphQLWithResponse call
+func ParsePostGraphQLResponse(rsp *http.Response) (*PostGraphQLResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostGraphQLResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GraphQLResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNftHistoryResponse parses an HTTP response from a GetNftHistoryWithResponse call
+func ParseGetNftHistoryResponse(rsp *http.Response) (*GetNftHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNftHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransferPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRefreshNftMetadataResponse parses an HTTP response from a RefreshNftMetadataWithResponse call
+func ParseRefreshNftMetadataResponse(rsp *http.Response) (*RefreshNftMetadataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefreshNftMetadataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest RefreshAccepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNftDataResponse parses an HTTP response from a GetNftDataWithResponse call
+func ParseGetNftDataResponse(rsp *http.Response) (*GetNftDataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNftDataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NFTResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReadinessResponse parses an HTTP response from a GetReadinessWithResponse call
+func ParseGetReadinessResponse(rsp *http.Response) (*GetReadinessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadinessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Readiness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamTransfersResponse parses an HTTP response from a StreamTransfersWithResponse call
+func ParseStreamTransfersResponse(rsp *http.Response) (*StreamTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamTransfersWebSocketResponse parses an HTTP response from a StreamTransfersWebSocketWithResponse call
+func ParseStreamTransfersWebSocketResponse(rsp *http.Response) (*StreamTransfersWebSocketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamTransfersWebSocketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
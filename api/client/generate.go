@@ -0,0 +1,5 @@
+// Package client is the typed Go client of the API, generated from api/openapi.json.
+// Run go generate ./client after changing the document
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.0 -config config.yaml ../openapi.json
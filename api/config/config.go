@@ -0,0 +1,277 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// API configuration, loaded from a YAML file then overridden by environment variables and flags
+type Config struct {
+	// Backend of the indexer database: "postgres" or "sqlite"
+	DatabaseDriver string `yaml:"database_driver"`
+	// Postgres connection string, or path of the SQLite file of the indexer
+	DatabaseURL string `yaml:"database_url"`
+	// Size of the connection pool shared by the requests
+	DBConnections int `yaml:"db_connections"`
+	// Address the HTTP server listens on
+	ListenAddress string `yaml:"listen_address"`
+	// Origins allowed to call the API from a browser
+	CORSOrigins []string `yaml:"cors_origins"`
+	// Chain served when a request has no chain parameter
+	DefaultChainID uint64 `yaml:"default_chain_id"`
+	// Deadline of the queries of a request
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// How long the requests in flight are waited for on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// Deepest selection a GraphQL query can have
+	GraphQLMaxDepth int `yaml:"graphql_max_depth"`
+	// Rows a GraphQL query can ask for, every list costs its first argument for each row of the lists above it
+	GraphQLMaxComplexity int `yaml:"graphql_max_complexity"`
+	// How often the streams look for new transfers with SQLite, Postgres notifies them
+	StreamPollInterval time.Duration `yaml:"stream_poll_interval"`
+	// Streams open at the same time
+	StreamMaxSubscribers int `yaml:"stream_max_subscribers"`
+	// Bearer token of the admin routes, they are disabled without it
+	AdminToken string `yaml:"admin_token"`
+	// Deliveries sent at the same time, 0 stops the delivery worker of this process
+	WebhookWorkers int `yaml:"webhook_workers"`
+	// Deadline of a delivery request
+	WebhookTimeout time.Duration `yaml:"webhook_timeout"`
+	// A delivery is given up after WebhookMaxAttempts failures, the delay between attempts doubles after every failure
+	WebhookMaxAttempts int           `yaml:"webhook_max_attempts"`
+	WebhookRetryDelay  time.Duration `yaml:"webhook_retry_delay"`
+}
+
+// Prefix of the environment variables, API_DATABASE_URL overrides database_url
+const envPrefix = "API_"
+
+func Default() Config {
+	return Config{
+		DatabaseDriver:  "postgres",
+		DBConnections:   10,
+		ListenAddress:   "localhost:8080",
+		CORSOrigins:     []string{"http://localhost:3000"},
+		DefaultChainID:  59144,
+		RequestTimeout:  10 * time.Second,
+		ShutdownTimeout: 30 * time.Second,
+
+		GraphQLMaxDepth:      8,
+		GraphQLMaxComplexity: 5000,
+
+		StreamPollInterval:   2 * time.Second,
+		StreamMaxSubscribers: 1000,
+
+		WebhookWorkers:     4,
+		WebhookTimeout:     10 * time.Second,
+		WebhookMaxAttempts: 8,
+		WebhookRetryDelay:  30 * time.Second,
+	}
+}
+
+// Load the configuration from the defaults, the file given by -config or API_CONFIG, the environment and the flags
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	// The file is read first so that the flags override it
+	path := os.Getenv(envPrefix + "CONFIG")
+	for i, arg := range args {
+		if arg == "-config" || arg == "--config" {
+			if i+1 < len(args) {
+				path = args[i+1]
+			}
+		} else if value, found := strings.CutPrefix(strings.TrimLeft(arg, "-"), "config="); found && strings.HasPrefix(arg, "-") {
+			path = value
+		}
+	}
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+		defer file.Close()
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(true)
+		// An empty file is valid and keeps the defaults
+		err = decoder.Decode(&cfg)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+
+	fields := cfg.fields()
+	for _, field := range fields {
+		value, ok := os.LookupEnv(envPrefix + strings.ToUpper(field.name))
+		if !ok {
+			continue
+		}
+		err := field.value.Set(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s%s: %w", envPrefix, strings.ToUpper(field.name), err)
+		}
+	}
+
+	flags := flag.NewFlagSet("api", flag.ContinueOnError)
+	flags.String("config", path, "path of the YAML configuration file")
+	for _, field := range fields {
+		flags.Var(field.value, strings.ReplaceAll(field.name, "_", "-"), field.usage)
+	}
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Check every setting and report all the invalid ones at once
+func (c *Config) Validate() error {
+	var problems []string
+	invalid := func(name string, format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("%s (%s in the file, %s%s or -%s): %s", name, name, envPrefix, strings.ToUpper(name), strings.ReplaceAll(name, "_", "-"), fmt.Sprintf(format, args...)))
+	}
+
+	if c.DatabaseDriver != "postgres" && c.DatabaseDriver != "sqlite" {
+		invalid("database_driver", "must be postgres or sqlite, got %q", c.DatabaseDriver)
+	}
+	if c.DatabaseURL == "" {
+		invalid("database_url", "is required")
+	}
+	if c.DBConnections < 1 {
+		invalid("db_connections", "must be at least 1")
+	}
+	if c.ListenAddress == "" {
+		invalid("listen_address", "is required")
+	}
+	if c.DefaultChainID == 0 {
+		invalid("default_chain_id", "is required")
+	}
+	if c.RequestTimeout <= 0 {
+		invalid("request_timeout", "must be a positive duration such as 10s")
+	}
+	if c.ShutdownTimeout <= 0 {
+		invalid("shutdown_timeout", "must be a positive duration such as 30s")
+	}
+	if c.GraphQLMaxDepth < 1 {
+		invalid("graphql_max_depth", "must be at least 1")
+	}
+	if c.GraphQLMaxComplexity < 1 {
+		invalid("graphql_max_complexity", "must be at least 1")
+	}
+	if c.StreamPollInterval <= 0 {
+		invalid("stream_poll_interval", "must be a positive duration such as 2s")
+	}
+	if c.StreamMaxSubscribers < 1 {
+		invalid("stream_max_subscribers", "must be at least 1")
+	}
+	if c.AdminToken != "" && len(c.AdminToken) < 16 {
+		invalid("admin_token", "must have at least 16 characters")
+	}
+	if c.WebhookWorkers < 0 {
+		invalid("webhook_workers", "must not be negative")
+	}
+	if c.WebhookTimeout <= 0 {
+		invalid("webhook_timeout", "must be a positive duration such as 10s")
+	}
+	if c.WebhookMaxAttempts < 1 {
+		invalid("webhook_max_attempts", "must be at least 1")
+	}
+	if c.WebhookRetryDelay <= 0 {
+		invalid("webhook_retry_delay", "must be a positive duration such as 30s")
+	}
+	for _, origin := range c.CORSOrigins {
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			invalid("cors_origins", "%q must be * or start with http:// or https://", origin)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration:\n  - " + strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// A setting that can be overridden by an environment variable and a flag
+type field struct {
+	name  string
+	usage string
+	value flag.Value
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{"database_driver", "database backend: postgres or sqlite", (*stringValue)(&c.DatabaseDriver)},
+		{"database_url", "postgres connection string or sqlite file path", (*stringValue)(&c.DatabaseURL)},
+		{"db_connections", "maximum number of open database connections", (*intValue)(&c.DBConnections)},
+		{"listen_address", "address the HTTP server listens on", (*stringValue)(&c.ListenAddress)},
+		{"cors_origins", "comma separated origins allowed to call the API", (*listValue)(&c.CORSOrigins)},
+		{"default_chain_id", "chain served when a request has no chain parameter", (*uint64Value)(&c.DefaultChainID)},
+		{"request_timeout", "deadline of the database queries of a request", (*durationValue)(&c.RequestTimeout)},
+		{"shutdown_timeout", "how long the requests in flight are waited for on shutdown", (*durationValue)(&c.ShutdownTimeout)},
+		{"graphql_max_depth", "deepest selection of a GraphQL query", (*intValue)(&c.GraphQLMaxDepth)},
+		{"graphql_max_complexity", "rows a GraphQL query can ask for", (*intValue)(&c.GraphQLMaxComplexity)},
+		{"stream_poll_interval", "how often the streams look for new transfers with sqlite", (*durationValue)(&c.StreamPollInterval)},
+		{"stream_max_subscribers", "streams open at the same time", (*intValue)(&c.StreamMaxSubscribers)},
+		{"admin_token", "bearer token of the admin routes, disabled when empty", (*stringValue)(&c.AdminToken)},
+		{"webhook_workers", "webhook deliveries sent at the same time, 0 disables the delivery worker", (*intValue)(&c.WebhookWorkers)},
+		{"webhook_timeout", "deadline of a webhook delivery request", (*durationValue)(&c.WebhookTimeout)},
+		{"webhook_max_attempts", "attempts before a webhook delivery is given up", (*intValue)(&c.WebhookMaxAttempts)},
+		{"webhook_retry_delay", "delay before the first retry of a webhook delivery, doubled after every failure", (*durationValue)(&c.WebhookRetryDelay)},
+	}
+}
+
+type stringValue string
+
+func (v *stringValue) String() string     { return string(*v) }
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+
+type intValue int
+
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+func (v *intValue) Set(s string) error {
+	parsed, err := strconv.Atoi(s)
+	*v = intValue(parsed)
+	return err
+}
+
+type uint64Value uint64
+
+func (v *uint64Value) String() string { return strconv.FormatUint(uint64(*v), 10) }
+func (v *uint64Value) Set(s string) error {
+	parsed, err := strconv.ParseUint(s, 10, 64)
+	*v = uint64Value(parsed)
+	return err
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+func (v *durationValue) Set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	*v = durationValue(parsed)
+	return err
+}
+
+type listValue []string
+
+func (v *listValue) String() string { return strings.Join(*v, ",") }
+func (v *listValue) Set(s string) error {
+	*v = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"workspace/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getERC1155History(c *gin.Context) {
+	collection, ok := addressParam(c, "collection")
+	if !ok {
+		return
+	}
+	tokenId, ok := tokenIdParam(c)
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	filter := database.TransferFilter{ChainId: chainId, Collection: collection, TokenId: tokenId}
+	txs, next, err := store.SelectERC1155Transfers(c.Request.Context(), filter, page)
+	listResponse(c, txs, next, err)
+}
+
+func getERC1155Data(c *gin.Context) {
+	collection, ok := addressParam(c, "collection")
+	if !ok {
+		return
+	}
+	tokenId, ok := tokenIdParam(c)
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+
+	token, found, err := store.SelectERC1155Token(c.Request.Context(), chainId, collection, tokenId)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	if !found {
+		abortWithError(c, http.StatusNotFound, codeNotFound, "unknown ERC-1155 token")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": token})
+}
+func getERC1155CollectionTokens(c *gin.Context) {
+	collection, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	tokens, next, err := store.SelectERC1155Tokens(c.Request.Context(), chainId, collection, page)
+	listResponse(c, tokens, next, err)
+}
+func getERC1155CollectionHistory(c *gin.Context) {
+	collection, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	txs, next, err := store.SelectERC1155Transfers(c.Request.Context(), database.TransferFilter{ChainId: chainId, Collection: collection}, page)
+	listResponse(c, txs, next, err)
+}
+func getERC1155CollectionStats(c *gin.Context) {
+	collection, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+
+	since := time.Now().Add(-24 * time.Hour).Unix()
+	stats, err := store.SelectERC1155CollectionStats(c.Request.Context(), chainId, collection, since)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": stats})
+}
+func getERC1155AddressHistory(c *gin.Context) {
+	address, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	txs, next, err := store.SelectERC1155Transfers(c.Request.Context(), database.TransferFilter{ChainId: chainId, Address: address}, page)
+	listResponse(c, txs, next, err)
+}
+func getERC1155AddressBalances(c *gin.Context) {
+	holder, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+	page, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	balances, next, err := store.SelectERC1155Balances(c.Request.Context(), chainId, holder, page)
+	listResponse(c, balances, next, err)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// Codes of the error responses, clients match them rather than the messages
+const (
+	codeInvalidAddress   = "invalid_address"
+	codeInvalidTokenId   = "invalid_token_id"
+	codeInvalidParameter = "invalid_parameter"
+	codeInvalidCursor    = "invalid_cursor"
+	codeNotFound         = "not_found"
+	codeInternal         = "internal_error"
+	codeUnavailable      = "unavailable"
+	codeUnauthorized     = "unauthorized"
+)
+
+// Every error is returned as {"error": {"code": ..., "message": ...}}
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func abortWithError(c *gin.Context, status int, code string, message string) {
+	c.AbortWithStatusJSON(status, gin.H{"error": apiError{Code: code, Message: message}})
+}
+
+func invalidParameter(c *gin.Context, message string) {
+	abortWithError(c, http.StatusBadRequest, codeInvalidParameter, message)
+}
+
+// Failures of the database, the request deadline included. The error is only logged, its message can show the queries
+func internalError(c *gin.Context, err error) {
+	log.Println(c.Request.Method, c.Request.URL.Path, ":", err)
+	abortWithError(c, http.StatusInternalServerError, codeInternal, "internal error")
+}
+
+// Address of a path parameter, see normalizeAddress
+func addressParam(c *gin.Context, name string) (string, bool) {
+	return validAddress(c, name, c.Param(name))
+}
+
+// Address of an optional query parameter, empty without it
+func addressQuery(c *gin.Context, name string) (string, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return "", true
+	}
+	return validAddress(c, name, value)
+}
+
+func validAddress(c *gin.Context, name string, value string) (string, bool) {
+	address, err := normalizeAddress(value)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, codeInvalidAddress, "invalid "+name+" "+strconv.Quote(value)+", "+err.Error())
+		return "", false
+	}
+	return address, true
+}
+
+// Token id of a path parameter, see normalizeTokenId
+func tokenIdParam(c *gin.Context) (string, bool) {
+	return validTokenId(c, c.Param("tokenId"))
+}
+
+// Token id of an optional query parameter, empty without it
+func tokenIdQuery(c *gin.Context) (string, bool) {
+	value := c.Query("tokenId")
+	if value == "" {
+		return "", true
+	}
+	return validTokenId(c, value)
+}
+
+func validTokenId(c *gin.Context, value string) (string, bool) {
+	tokenId, err := normalizeTokenId(value)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, codeInvalidTokenId, "invalid token id "+strconv.Quote(value)+", "+err.Error())
+		return "", false
+	}
+	return tokenId, true
+}
+
+var hexAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Lowercase and uppercase addresses are taken as they are, mixed case ones must have a valid EIP-55 checksum.
+// The address is returned lowercase like in the responses
+func normalizeAddress(value string) (string, error) {
+	if !hexAddressPattern.MatchString(value) {
+		return "", errors.New("expected a 0x prefixed hex address")
+	}
+	digits := value[2:]
+	mixedCase := digits != strings.ToLower(digits) && digits != strings.ToUpper(digits)
+	if mixedCase && common.HexToAddress(value).Hex() != value {
+		return "", errors.New("wrong checksum")
+	}
+	return strings.ToLower(value), nil
+}
+
+var tokenIdPattern = regexp.MustCompile(`^[0-9]{1,78}$`)
+
+var maxTokenId = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// A token id is a decimal uint256, returned without its leading zeros
+func normalizeTokenId(value string) (string, error) {
+	tokenId, _ := new(big.Int).SetString(value, 10)
+	if !tokenIdPattern.MatchString(value) || tokenId.Cmp(maxTokenId) > 0 {
+		return "", errors.New("expected a decimal uint256")
+	}
+	return tokenId.String(), nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"workspace/customTypes"
+	"workspace/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Types of the GraphQL endpoint, resolved by graphql_resolvers.go
+//
+//go:embed schema.graphql
+var graphQLSchema string
+
+func newGraphQLSchema() *graphql.Schema {
+	return graphql.MustParseSchema(graphQLSchema, &queryResolver{}, graphql.MaxDepth(cfg.GraphQLMaxDepth))
+}
+
+// Same schema for the parser computing the cost of the queries, graphql-go does not expose the queries it parses
+var graphQLCostSchema = gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: graphQLSchema})
+
+// Body of a GraphQL request
+type graphQLParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Run a query, its errors are returned in the errors of the response with a 200
+func graphQLHandler(schema *graphql.Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var params graphQLParams
+		err := c.ShouldBindJSON(&params)
+		if err != nil || params.Query == "" {
+			invalidParameter(c, "expected a JSON body with a query")
+			return
+		}
+
+		if queryCost(params) > int64(cfg.GraphQLMaxComplexity) {
+			err := gqlerrors.Errorf("query too complex: it can ask for more than %d rows, lower the first arguments", cfg.GraphQLMaxComplexity)
+			c.JSON(http.StatusOK, &graphql.Response{Errors: []*gqlerrors.QueryError{err}})
+			return
+		}
+		ctx := context.WithValue(c.Request.Context(), graphQLRequestKey{}, newGraphQLRequest())
+		c.JSON(http.StatusOK, schema.Exec(ctx, params.Query, params.OperationName, params.Variables))
+	}
+}
+
+// State of a query: its cost budget and the loaders of the rows it references
+type graphQLRequest struct {
+	// Rows the query can still ask for
+	budget      atomic.Int64
+	collections *loader[collectionKey, customTypes.ERC721CollectionStruct]
+	tokens      *loader[tokenKey, customTypes.NFTStruct]
+}
+
+type graphQLRequestKey struct{}
+
+type collectionKey struct {
+	chainId uint64
+	address string
+}
+
+type tokenKey struct {
+	chainId    uint64
+	collection string
+	tokenId    string
+}
+
+func newGraphQLRequest() *graphQLRequest {
+	request := &graphQLRequest{collections: newLoader(fetchCollections), tokens: newLoader(fetchTokens)}
+	request.budget.Store(int64(cfg.GraphQLMaxComplexity))
+	return request
+}
+
+func requestOf(ctx context.Context) *graphQLRequest {
+	return ctx.Value(graphQLRequestKey{}).(*graphQLRequest)
+}
+
+// Rows a query can ask for before it runs: a list asks for its first argument and the lists under it for each of these rows.
+// The invalid queries cost 0 and are rejected by Exec, the cost is capped above the budget
+func queryCost(params graphQLParams) int64 {
+	document, errs := gqlparser.LoadQuery(graphQLCostSchema, params.Query)
+	if len(errs) > 0 {
+		return 0
+	}
+	operation := document.Operations.ForName(params.OperationName)
+	if params.OperationName == "" && len(document.Operations) == 1 {
+		operation = document.Operations[0]
+	}
+	if operation == nil {
+		return 0
+	}
+	return selectionCost(operation.SelectionSet, params.Variables, int64(cfg.GraphQLMaxComplexity)+1)
+}
+
+func selectionCost(selections ast.SelectionSet, variables map[string]interface{}, limit int64) (cost int64) {
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Definition == nil {
+				continue
+			}
+			children := selectionCost(selection.SelectionSet, variables, limit)
+			if selection.Definition.Arguments.ForName("first") == nil {
+				cost += children
+				break
+			}
+			rows := limit
+			switch first := selection.ArgumentMap(variables)["first"].(type) {
+			case int64:
+				rows = first
+			case float64:
+				rows = int64(first)
+			}
+			if rows < 1 || rows > limit {
+				rows = limit
+			}
+			cost += rows * (1 + children)
+		case *ast.InlineFragment:
+			cost += selectionCost(selection.SelectionSet, variables, limit)
+		case *ast.FragmentSpread:
+			cost += selectionCost(selection.Definition.SelectionSet, variables, limit)
+		}
+		if cost > limit {
+			return limit
+		}
+	}
+	return cost
+}
+
+// Take the cost of a field from the budget before reading its rows
+func charge(ctx context.Context, cost int32) error {
+	if requestOf(ctx).budget.Add(-int64(cost)) < 0 {
+		return fmt.Errorf("query too complex: it asks for more than %d rows, lower the first arguments", cfg.GraphQLMaxComplexity)
+	}
+	return nil
+}
+
+func fetchCollections(ctx context.Context, keys []collectionKey) (map[collectionKey]customTypes.ERC721CollectionStruct, error) {
+	byChain := map[uint64][]string{}
+	for _, key := range keys {
+		byChain[key.chainId] = append(byChain[key.chainId], key.address)
+	}
+	rows := map[collectionKey]customTypes.ERC721CollectionStruct{}
+	for chainId, addresses := range byChain {
+		collections, err := store.SelectCollections(ctx, chainId, addresses)
+		if err != nil {
+			return nil, err
+		}
+		for _, collection := range collections {
+			rows[collectionKey{chainId, strings.ToLower(collection.ContractAddress.Hex())}] = collection
+		}
+	}
+	return rows, nil
+}
+
+func fetchTokens(ctx context.Context, keys []tokenKey) (map[tokenKey]customTypes.NFTStruct, error) {
+	byChain := map[uint64][]database.TokenKey{}
+	for _, key := range keys {
+		byChain[key.chainId] = append(byChain[key.chainId], database.TokenKey{Collection: key.collection, TokenId: key.tokenId})
+	}
+	rows := map[tokenKey]customTypes.NFTStruct{}
+	for chainId, tokenKeys := range byChain {
+		nfts, err := store.SelectNFTsByKey(ctx, chainId, tokenKeys)
+		if err != nil {
+			return nil, err
+		}
+		for _, nft := range nfts {
+			rows[tokenKey{chainId, strings.ToLower(nft.Collection.Hex()), nft.TokenId}] = nft
+		}
+	}
+	return rows, nil
+}
+
+// 64-bit integer of the schema
+type long int64
+
+func (long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+// Variables are decoded from JSON as float64, literals come as int32 or as a string when they overflow it
+func (l *long) UnmarshalGraphQL(input interface{}) error {
+	switch value := input.(type) {
+	case int32:
+		*l = long(value)
+	case float64:
+		if value != float64(int64(value)) {
+			return fmt.Errorf("invalid Long %v, expected an integer", value)
+		}
+		*l = long(value)
+	case string:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Long %q, expected an integer", value)
+		}
+		*l = long(parsed)
+	default:
+		return fmt.Errorf("invalid Long %v, expected an integer", input)
+	}
+	return nil
+}
+
+func (l long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(l), 10), nil
+}
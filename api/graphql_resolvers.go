@@ -0,0 +1,466 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"workspace/customTypes"
+	"workspace/database"
+)
+
+// Chain of a chain argument, the configured default without it
+func chainArg(chain *long) (uint64, error) {
+	if chain == nil {
+		return cfg.DefaultChainID, nil
+	}
+	if *chain <= 0 {
+		return 0, fmt.Errorf("invalid chain %d, expected a chain id", *chain)
+	}
+	return uint64(*chain), nil
+}
+
+func addressArg(name string, value string) (string, error) {
+	address, err := normalizeAddress(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s %q, %w", name, value, err)
+	}
+	return address, nil
+}
+
+// Page of a list field, charged for its first argument
+func pageArg(ctx context.Context, first int32, after *string) (database.Page, error) {
+	if first < 1 || first > maxPageSize {
+		return database.Page{}, fmt.Errorf("invalid first %d, expected a number between 1 and %d", first, maxPageSize)
+	}
+	err := charge(ctx, first)
+	if err != nil {
+		return database.Page{}, err
+	}
+	page := database.Page{Limit: int(first)}
+	if after != nil {
+		page.Cursor = *after
+	}
+	return page, nil
+}
+
+// Tags are lowercase in the database and uppercase in the schema
+func tagArg(tag *string) string {
+	if tag == nil {
+		return ""
+	}
+	return strings.ToLower(*tag)
+}
+
+// ///////////////////////////////////// QUERY ///////////////////////////////////////
+type queryResolver struct{}
+
+func (queryResolver) Collection(ctx context.Context, args struct {
+	Address string
+	Chain   *long
+}) (*collectionResolver, error) {
+	chainId, err := chainArg(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	address, err := addressArg("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+	err = charge(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	return loadCollection(ctx, chainId, address)
+}
+
+func (queryResolver) Token(ctx context.Context, args struct {
+	Collection string
+	TokenId    string
+	Chain      *long
+}) (*tokenResolver, error) {
+	chainId, err := chainArg(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := addressArg("collection", args.Collection)
+	if err != nil {
+		return nil, err
+	}
+	tokenId, err := normalizeTokenId(args.TokenId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tokenId %q, %w", args.TokenId, err)
+	}
+	err = charge(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	nft, found, err := requestOf(ctx).tokens.load(ctx, tokenKey{chainId, collection, tokenId})
+	if err != nil || !found {
+		return nil, err
+	}
+	return &tokenResolver{chainId: chainId, nft: nft, withMetadata: true}, nil
+}
+
+func (queryResolver) Account(args struct {
+	Address string
+	Chain   *long
+}) (*accountResolver, error) {
+	chainId, err := chainArg(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	address, err := addressArg("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &accountResolver{chainId: chainId, address: address}, nil
+}
+
+type transferFilterInput struct {
+	Collection *string
+	TokenId    *string
+	Address    *string
+	Tag        *string
+}
+
+func (queryResolver) Transfers(ctx context.Context, args struct {
+	Filter *transferFilterInput
+	First  int32
+	After  *string
+	Chain  *long
+}) (*transferConnection, error) {
+	chainId, err := chainArg(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	filter := database.TransferFilter{ChainId: chainId}
+	if args.Filter != nil {
+		if args.Filter.Collection != nil {
+			filter.Collection, err = addressArg("collection", *args.Filter.Collection)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if args.Filter.TokenId != nil {
+			filter.TokenId, err = normalizeTokenId(*args.Filter.TokenId)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tokenId %q, %w", *args.Filter.TokenId, err)
+			}
+		}
+		if args.Filter.Address != nil {
+			filter.Address, err = addressArg("address", *args.Filter.Address)
+			if err != nil {
+				return nil, err
+			}
+		}
+		filter.Tag = tagArg(args.Filter.Tag)
+	}
+	return selectTransfers(ctx, filter, args.First, args.After)
+}
+
+// ///////////////////////////////////// COLLECTION ///////////////////////////////////////
+type collectionResolver struct {
+	chainId    uint64
+	collection customTypes.ERC721CollectionStruct
+}
+
+// Nil for a collection that is not indexed
+func loadCollection(ctx context.Context, chainId uint64, address string) (*collectionResolver, error) {
+	collection, found, err := requestOf(ctx).collections.load(ctx, collectionKey{chainId, address})
+	if err != nil || !found {
+		return nil, err
+	}
+	return &collectionResolver{chainId: chainId, collection: collection}, nil
+}
+
+func (r *collectionResolver) ChainId() long { return long(r.chainId) }
+func (r *collectionResolver) Address() string {
+	return strings.ToLower(r.collection.ContractAddress.Hex())
+}
+func (r *collectionResolver) Name() string            { return r.collection.ContractName }
+func (r *collectionResolver) Symbol() string          { return r.collection.ContractSymbol }
+func (r *collectionResolver) DeployBlockNumber() long { return long(r.collection.DeployBlockNumber) }
+func (r *collectionResolver) DeployTimestamp() long   { return long(r.collection.DeployTimestamp) }
+func (r *collectionResolver) DeployTxHash() string    { return r.collection.DeployTxHash }
+
+type traitFilterInput struct {
+	TraitType string
+	Values    []string
+}
+
+func (r *collectionResolver) Tokens(ctx context.Context, args struct {
+	First   int32
+	After   *string
+	OrderBy string
+	Traits  *[]traitFilterInput
+}) (*tokenConnection, error) {
+	filter := database.NFTFilter{ChainId: r.chainId, Collection: r.Address(), ByRarity: args.OrderBy == "RARITY"}
+	if args.Traits != nil {
+		filter.Traits = map[string][]string{}
+		for _, trait := range *args.Traits {
+			filter.Traits[trait.TraitType] = append(filter.Traits[trait.TraitType], trait.Values...)
+		}
+	}
+	return selectTokens(ctx, filter, args.First, args.After)
+}
+
+func (r *collectionResolver) Transfers(ctx context.Context, args struct {
+	First int32
+	After *string
+	Tag   *string
+}) (*transferConnection, error) {
+	return selectTransfers(ctx, database.TransferFilter{ChainId: r.chainId, Collection: r.Address(), Tag: tagArg(args.Tag)}, args.First, args.After)
+}
+
+func (r *collectionResolver) Traits(ctx context.Context) ([]*traitCountResolver, error) {
+	err := charge(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	traits, err := store.SelectTraitCounts(ctx, r.chainId, r.Address())
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*traitCountResolver, len(traits))
+	for i := range traits {
+		resolvers[i] = &traitCountResolver{traits[i]}
+	}
+	return resolvers, nil
+}
+
+type traitCountResolver struct {
+	trait customTypes.TraitCountStruct
+}
+
+func (r *traitCountResolver) TraitType() string { return r.trait.TraitType }
+func (r *traitCountResolver) Value() string     { return r.trait.Value }
+func (r *traitCountResolver) Count() int32      { return int32(r.trait.Count) }
+
+// ///////////////////////////////////// TOKEN ///////////////////////////////////////
+type tokenResolver struct {
+	chainId uint64
+	nft     customTypes.NFTStruct
+	// The lists read the NFTs without their metadata
+	withMetadata bool
+}
+
+func (r *tokenResolver) ChainId() long { return long(r.chainId) }
+func (r *tokenResolver) Collection(ctx context.Context) (*collectionResolver, error) {
+	return loadCollection(ctx, r.chainId, r.CollectionAddress())
+}
+func (r *tokenResolver) CollectionAddress() string {
+	return strings.ToLower(r.nft.Collection.Hex())
+}
+func (r *tokenResolver) TokenId() string { return r.nft.TokenId }
+func (r *tokenResolver) Uri() string     { return r.nft.URI }
+func (r *tokenResolver) Owner() *accountResolver {
+	return &accountResolver{chainId: r.chainId, address: strings.ToLower(r.nft.Owner.Hex())}
+}
+func (r *tokenResolver) MintBlockNumber() long { return long(r.nft.MintBlockNumber) }
+func (r *tokenResolver) MintTimestamp() long   { return long(r.nft.MintTimestamp) }
+func (r *tokenResolver) MintTxHash() string    { return r.nft.MintTxHash }
+
+func (r *tokenResolver) Metadata(ctx context.Context) (*metadataResolver, error) {
+	metadata := r.nft.Metadata
+	if !r.withMetadata {
+		nft, _, err := requestOf(ctx).tokens.load(ctx, tokenKey{r.chainId, r.CollectionAddress(), r.nft.TokenId})
+		if err != nil {
+			return nil, err
+		}
+		metadata = nft.Metadata
+	}
+	if metadata == nil {
+		return nil, nil
+	}
+	return &metadataResolver{metadata}, nil
+}
+
+func (r *tokenResolver) Rarity() *rarityResolver {
+	if r.nft.Rarity == nil {
+		return nil
+	}
+	return &rarityResolver{r.nft.Rarity}
+}
+
+func (r *tokenResolver) Transfers(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (*transferConnection, error) {
+	return selectTransfers(ctx, database.TransferFilter{ChainId: r.chainId, Collection: r.CollectionAddress(), TokenId: r.nft.TokenId}, args.First, args.After)
+}
+
+type metadataResolver struct {
+	metadata *customTypes.NFTMetadataStruct
+}
+
+func (r *metadataResolver) Status() string      { return r.metadata.Status }
+func (r *metadataResolver) Name() string        { return r.metadata.Name }
+func (r *metadataResolver) Description() string { return r.metadata.Description }
+func (r *metadataResolver) Image() string       { return r.metadata.Image }
+func (r *metadataResolver) LastError() string   { return r.metadata.LastError }
+func (r *metadataResolver) Attributes() *string {
+	if len(r.metadata.Attributes) == 0 {
+		return nil
+	}
+	attributes := string(r.metadata.Attributes)
+	return &attributes
+}
+func (r *metadataResolver) FetchedAt() *long {
+	if r.metadata.FetchedAt == nil {
+		return nil
+	}
+	fetchedAt := long(*r.metadata.FetchedAt)
+	return &fetchedAt
+}
+
+type rarityResolver struct {
+	rarity *customTypes.RarityStruct
+}
+
+func (r *rarityResolver) Score() float64 { return r.rarity.Score }
+func (r *rarityResolver) Rank() int32    { return int32(r.rarity.Rank) }
+
+// ///////////////////////////////////// TRANSFER ///////////////////////////////////////
+type transferResolver struct {
+	tx customTypes.ERC721TxStruct
+}
+
+func (r *transferResolver) ChainId() long     { return long(r.tx.ChainId) }
+func (r *transferResolver) BlockNumber() long { return long(r.tx.BlockNumber) }
+func (r *transferResolver) Timestamp() long   { return long(r.tx.Timestamp) }
+func (r *transferResolver) TxHash() string    { return r.tx.TxHash }
+func (r *transferResolver) LogIndex() int32   { return int32(r.tx.LogIndex) }
+func (r *transferResolver) Tag() string       { return strings.ToUpper(r.tx.Tag) }
+func (r *transferResolver) From() *accountResolver {
+	return &accountResolver{chainId: r.tx.ChainId, address: strings.ToLower(r.tx.FromAddr.Hex())}
+}
+func (r *transferResolver) To() *accountResolver {
+	return &accountResolver{chainId: r.tx.ChainId, address: strings.ToLower(r.tx.ToAddr.Hex())}
+}
+func (r *transferResolver) Collection(ctx context.Context) (*collectionResolver, error) {
+	return loadCollection(ctx, r.tx.ChainId, r.CollectionAddress())
+}
+func (r *transferResolver) CollectionAddress() string {
+	return strings.ToLower(r.tx.Collection.Hex())
+}
+func (r *transferResolver) Token(ctx context.Context) (*tokenResolver, error) {
+	nft, found, err := requestOf(ctx).tokens.load(ctx, tokenKey{r.tx.ChainId, r.CollectionAddress(), r.tx.TokenId})
+	if err != nil || !found {
+		return nil, err
+	}
+	return &tokenResolver{chainId: r.tx.ChainId, nft: nft, withMetadata: true}, nil
+}
+func (r *transferResolver) TokenId() string      { return r.tx.TokenId }
+func (r *transferResolver) Price() *string       { return optional(r.tx.Price) }
+func (r *transferResolver) Currency() string     { return strings.ToLower(r.tx.Currency.Hex()) }
+func (r *transferResolver) PriceSource() *string { return optional(r.tx.PriceSource) }
+func (r *transferResolver) Confidence() *string  { return optional(r.tx.Confidence) }
+
+// Null for an empty string
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// ///////////////////////////////////// ACCOUNT ///////////////////////////////////////
+type accountResolver struct {
+	chainId uint64
+	address string
+}
+
+func (r *accountResolver) Address() string { return r.address }
+
+func (r *accountResolver) Tokens(ctx context.Context, args struct {
+	First      int32
+	After      *string
+	Collection *string
+}) (*tokenConnection, error) {
+	filter := database.NFTFilter{ChainId: r.chainId, Owner: r.address}
+	if args.Collection != nil {
+		collection, err := addressArg("collection", *args.Collection)
+		if err != nil {
+			return nil, err
+		}
+		filter.Collection = collection
+	}
+	return selectTokens(ctx, filter, args.First, args.After)
+}
+
+func (r *accountResolver) Transfers(ctx context.Context, args struct {
+	First int32
+	After *string
+	Tag   *string
+}) (*transferConnection, error) {
+	return selectTransfers(ctx, database.TransferFilter{ChainId: r.chainId, Address: r.address, Tag: tagArg(args.Tag)}, args.First, args.After)
+}
+
+// ///////////////////////////////////// CONNECTIONS ///////////////////////////////////////
+// Read a page of NFTs and register their collections and metadata with the loaders
+func selectTokens(ctx context.Context, filter database.NFTFilter, first int32, after *string) (*tokenConnection, error) {
+	page, err := pageArg(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+	nfts, next, err := store.SelectNFTs(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	request := requestOf(ctx)
+	connection := &tokenConnection{nodes: make([]*tokenResolver, len(nfts)), pageInfo: pageInfo{next}}
+	for i, nft := range nfts {
+		collection := strings.ToLower(nft.Collection.Hex())
+		request.collections.prime(collectionKey{filter.ChainId, collection})
+		request.tokens.prime(tokenKey{filter.ChainId, collection, nft.TokenId})
+		connection.nodes[i] = &tokenResolver{chainId: filter.ChainId, nft: nft}
+	}
+	return connection, nil
+}
+
+// Read a page of transfers and register their collections and tokens with the loaders
+func selectTransfers(ctx context.Context, filter database.TransferFilter, first int32, after *string) (*transferConnection, error) {
+	page, err := pageArg(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+	txs, next, err := store.SelectTransfers(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	request := requestOf(ctx)
+	connection := &transferConnection{nodes: make([]*transferResolver, len(txs)), pageInfo: pageInfo{next}}
+	for i, tx := range txs {
+		collection := strings.ToLower(tx.Collection.Hex())
+		request.collections.prime(collectionKey{tx.ChainId, collection})
+		request.tokens.prime(tokenKey{tx.ChainId, collection, tx.TokenId})
+		connection.nodes[i] = &transferResolver{tx}
+	}
+	return connection, nil
+}
+
+type tokenConnection struct {
+	nodes    []*tokenResolver
+	pageInfo pageInfo
+}
+
+func (c *tokenConnection) Nodes() []*tokenResolver { return c.nodes }
+func (c *tokenConnection) PageInfo() pageInfo      { return c.pageInfo }
+
+type transferConnection struct {
+	nodes    []*transferResolver
+	pageInfo pageInfo
+}
+
+func (c *transferConnection) Nodes() []*transferResolver { return c.nodes }
+func (c *transferConnection) PageInfo() pageInfo         { return c.pageInfo }
+
+type pageInfo struct {
+	next string
+}
+
+func (p pageInfo) EndCursor() *string { return optional(p.next) }
+func (p pageInfo) HasNextPage() bool  { return p.next != "" }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"workspace/database/dbtest"
+)
+
+type graphQLResult struct {
+	Data   map[string]interface{}
+	Errors []struct{ Message string }
+}
+
+func postGraphQL(t *testing.T, server *httptest.Server, query string, variables map[string]interface{}) graphQLResult {
+	t.Helper()
+	body, _ := json.Marshal(graphQLParams{Query: query, Variables: variables})
+	resp, err := http.Post(server.URL+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result graphQLResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("answered %d: %v", resp.StatusCode, err)
+	}
+	return result
+}
+
+func TestGraphQLDepthLimit(t *testing.T) {
+	server := startAPI(t)
+	seed(t)
+	collection := dbtest.Collection.Hex()
+
+	result := postGraphQL(t, server, `{ token(collection: "`+collection+`", tokenId: "1") { owner { address } collection { name } } }`, nil)
+	if len(result.Errors) != 0 || result.Data["token"] == nil {
+		t.Fatalf("query within the depth rejected: %+v", result)
+	}
+
+	// 9 levels of selections, the default limit is 8
+	deep := `{ token(collection: "` + collection + `", tokenId: "1") { collection { tokens(first: 1) { nodes { owner { transfers(first: 1) { nodes { token { tokenId } } } } } } } } }`
+	result = postGraphQL(t, server, deep, nil)
+	if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, "depth") || result.Data != nil {
+		t.Errorf("query deeper than the limit answered %+v", result)
+	}
+}
+
+func TestGraphQLComplexityLimit(t *testing.T) {
+	server := startAPI(t)
+	seed(t)
+	collection := dbtest.Collection.Hex()
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		// Over the default budget of 5000 rows
+		rejected bool
+	}{
+		{"single list", `{ transfers(first: 1000) { nodes { txHash } } }`, nil, false},
+		{"nested lists within the budget", `{ transfers(first: 100) { nodes { token { transfers(first: 40) { nodes { txHash } } } } } }`, nil, false},
+		{"nested lists", `{ transfers(first: 100) { nodes { token { transfers(first: 50) { nodes { txHash } } } } } }`, nil, true},
+		{"default first", `{ collection(address: "` + collection + `") { tokens { nodes { transfers { nodes { token { transfers { nodes { txHash } } } } } } } } }`, nil, true},
+		{"variables", `query($first: Int) { transfers(first: $first) { nodes { token { transfers(first: $first) { nodes { txHash } } } } } }`, map[string]interface{}{"first": 100}, true},
+		{"fragments", `{ transfers(first: 100) { ...nested } } fragment nested on TransferConnection { nodes { token { transfers(first: 50) { nodes { txHash } } } } }`, nil, true},
+		{"aliases", `{ a: transfers(first: 1000) { nodes { txHash } } b: transfers(first: 1000) { nodes { txHash } } c: transfers(first: 1000) { nodes { txHash } } d: transfers(first: 1000) { nodes { txHash } } e: transfers(first: 1000) { nodes { txHash } } f: transfers(first: 1) { nodes { txHash } } }`, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := postGraphQL(t, server, test.query, test.variables)
+			rejected := len(result.Errors) > 0 && strings.Contains(result.Errors[0].Message, "too complex")
+			if rejected != test.rejected {
+				t.Errorf("rejected is %v, expected %v: %+v", rejected, test.rejected, result)
+			}
+			if test.rejected && result.Data != nil {
+				t.Errorf("a rejected query ran: %+v", result.Data)
+			}
+			if !test.rejected && len(result.Errors) > 0 {
+				t.Errorf("errors %+v", result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Set once the server stops taking new requests
+var draining atomic.Bool
+
+// Cancel the queries of a request once request_timeout is over
+func requestTimeout(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), cfg.RequestTimeout)
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// Liveness: the process answers, the database status is reported without failing since restarting would not fix it
+func getHealth(c *gin.Context) {
+	database := "up"
+	if store.Ping(c.Request.Context()) != nil {
+		database = "down"
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "database": database})
+}
+
+// Readiness: the database can be reached and the server is not shutting down
+func getReadiness(c *gin.Context) {
+	if draining.Load() {
+		abortWithError(c, http.StatusServiceUnavailable, codeUnavailable, "shutting down")
+		return
+	}
+	err := store.Ping(c.Request.Context())
+	if err != nil {
+		abortWithError(c, http.StatusServiceUnavailable, codeUnavailable, "database unreachable: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ready"})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// Batches the reads of the rows referenced by a GraphQL response. The lists register the keys of their rows
+// with prime, the first load fetches every registered key in one query and the next ones read the cache
+type loader[K comparable, V any] struct {
+	mu      sync.Mutex
+	pending map[K]bool
+	loaded  map[K]V
+	// Fetched keys without a row
+	missing map[K]bool
+	// Keys missing from the result are unknown rows
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{pending: map[K]bool{}, loaded: map[K]V{}, missing: map[K]bool{}, fetch: fetch}
+}
+
+func (l *loader[K, V]) prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if _, found := l.loaded[key]; !found && !l.missing[key] {
+			l.pending[key] = true
+		}
+	}
+}
+
+// Load a row with the pending ones, found is false for an unknown row
+func (l *loader[K, V]) load(ctx context.Context, key K) (value V, found bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if value, found = l.loaded[key]; found || l.missing[key] {
+		return value, found, nil
+	}
+
+	l.pending[key] = true
+	keys := make([]K, 0, len(l.pending))
+	for pending := range l.pending {
+		keys = append(keys, pending)
+	}
+	values, err := l.fetch(ctx, keys)
+	if err != nil {
+		return value, false, err
+	}
+	for _, fetched := range keys {
+		delete(l.pending, fetched)
+		if row, found := values[fetched]; found {
+			l.loaded[fetched] = row
+		} else {
+			l.missing[fetched] = true
+		}
+	}
+	value, found = l.loaded[key]
+	return value, found, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Ask the indexer to fetch the metadata of an NFT again
+func refreshNftMetadata(c *gin.Context) {
+	collection, ok := addressParam(c, "collection")
+	if !ok {
+		return
+	}
+	tokenId, ok := tokenIdParam(c)
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+
+	found, err := store.RefreshMetadata(c.Request.Context(), chainId, collection, tokenId)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	if !found {
+		abortWithError(c, http.StatusNotFound, codeNotFound, "unknown NFT")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"data": gin.H{"status": "pending"}})
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api/client"
+	"workspace/database/dbtest"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// Sends the requests of the generated client and checks them and their responses against openapi.json
+type validatingDoer struct {
+	t      *testing.T
+	router routers.Router
+	// Operations called, by operationId
+	called map[string]bool
+}
+
+func (d *validatingDoer) Do(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		body, _ = io.ReadAll(request.Body)
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	route, params, err := d.router.FindRoute(request)
+	if err != nil {
+		d.t.Fatalf("%s %s is not documented: %v", request.Method, request.URL.Path, err)
+	}
+	d.called[route.Operation.OperationID] = true
+	options := &openapi3filter.Options{IncludeResponseStatus: true, AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	input := &openapi3filter.RequestValidationInput{Request: request, PathParams: params, Route: route, Options: options}
+	err = openapi3filter.ValidateRequest(request.Context(), input)
+	if err != nil {
+		d.t.Errorf("%s: invalid request: %v", route.Operation.OperationID, err)
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	err = openapi3filter.ValidateResponse(request.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 response.StatusCode,
+		Header:                 response.Header,
+		Body:                   io.NopCloser(bytes.NewReader(responseBody)),
+		Options:                options,
+	})
+	if err != nil {
+		d.t.Errorf("%s answered %d against the document: %v\n%s", route.Operation.OperationID, response.StatusCode, err, responseBody)
+	}
+	return response, nil
+}
+
+// Every documented operation is called through the generated client on a seeded database, and answers what the document describes
+func TestOpenAPIDocument(t *testing.T) {
+	server := startAPI(t)
+	seed(t)
+	cfg.AdminToken = "admin-token"
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(receiver.Close)
+
+	ctx := context.Background()
+	document, err := openapi3.NewLoader().LoadFromData(openAPIDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = document.Validate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	document.Servers = openapi3.Servers{{URL: server.URL}}
+	router, err := legacy.NewRouter(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doer := &validatingDoer{t: t, router: router, called: map[string]bool{}}
+	api, err := client.NewClientWithResponses(server.URL, client.WithHTTPClient(doer), client.WithRequestEditorFn(func(ctx context.Context, request *http.Request) error {
+		request.Header.Set("Authorization", "Bearer "+cfg.AdminToken)
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection, erc1155 := dbtest.Collection.Hex(), dbtest.ERC1155.Hex()
+	limit := 1
+	statuses := map[string]func() (int, error){
+		"getHealth":    func() (int, error) { r, err := api.GetHealthWithResponse(ctx); return status(r, err) },
+		"getReadiness": func() (int, error) { r, err := api.GetReadinessWithResponse(ctx); return status(r, err) },
+		"getNftData": func() (int, error) {
+			r, err := api.GetNftDataWithResponse(ctx, collection, "1", nil)
+			return status(r, err)
+		},
+		"unknown nft": func() (int, error) {
+			r, err := api.GetNftDataWithResponse(ctx, collection, "3", nil)
+			return status(r, err)
+		},
+		"getNftHistory": func() (int, error) {
+			r, err := api.GetNftHistoryWithResponse(ctx, collection, "1", nil)
+			return status(r, err)
+		},
+		"refreshNftMetadata": func() (int, error) {
+			r, err := api.RefreshNftMetadataWithResponse(ctx, collection, "1", nil)
+			return status(r, err)
+		},
+		"getCollectionNfts": func() (int, error) {
+			r, err := api.GetCollectionNftsWithResponse(ctx, collection, &client.GetCollectionNftsParams{Limit: &limit})
+			return status(r, err)
+		},
+		"getCollectionHistory": func() (int, error) {
+			r, err := api.GetCollectionHistoryWithResponse(ctx, collection, nil)
+			return status(r, err)
+		},
+		"getCollectionStats": func() (int, error) {
+			r, err := api.GetCollectionStatsWithResponse(ctx, collection, nil)
+			return status(r, err)
+		},
+		"getCollectionStatsSeries": func() (int, error) {
+			r, err := api.GetCollectionStatsSeriesWithResponse(ctx, collection, nil)
+			return status(r, err)
+		},
+		"getCollectionTraits": func() (int, error) {
+			r, err := api.GetCollectionTraitsWithResponse(ctx, collection, nil)
+			return status(r, err)
+		},
+		"getAddressNfts": func() (int, error) {
+			r, err := api.GetAddressNftsWithResponse(ctx, dbtest.Alice.Hex(), nil)
+			return status(r, err)
+		},
+		"getAddressHistory": func() (int, error) {
+			r, err := api.GetAddressHistoryWithResponse(ctx, dbtest.Bob.Hex(), nil)
+			return status(r, err)
+		},
+		"getERC1155Data": func() (int, error) {
+			r, err := api.GetERC1155DataWithResponse(ctx, erc1155, "7", nil)
+			return status(r, err)
+		},
+		"getERC1155History": func() (int, error) {
+			r, err := api.GetERC1155HistoryWithResponse(ctx, erc1155, "7", nil)
+			return status(r, err)
+		},
+		"getERC1155CollectionTokens": func() (int, error) {
+			r, err := api.GetERC1155CollectionTokensWithResponse(ctx, erc1155, nil)
+			return status(r, err)
+		},
+		"getERC1155CollectionHistory": func() (int, error) {
+			r, err := api.GetERC1155CollectionHistoryWithResponse(ctx, erc1155, nil)
+			return status(r, err)
+		},
+		"getERC1155CollectionStats": func() (int, error) {
+			r, err := api.GetERC1155CollectionStatsWithResponse(ctx, erc1155, nil)
+			return status(r, err)
+		},
+		"getERC1155AddressBalances": func() (int, error) {
+			r, err := api.GetERC1155AddressBalancesWithResponse(ctx, dbtest.Alice.Hex(), nil)
+			return status(r, err)
+		},
+		"getERC1155AddressHistory": func() (int, error) {
+			r, err := api.GetERC1155AddressHistoryWithResponse(ctx, dbtest.Alice.Hex(), nil)
+			return status(r, err)
+		},
+		"postGraphQL": func() (int, error) {
+			r, err := api.PostGraphQLWithResponse(ctx, client.GraphQLRequest{Query: `{ nft(collection: "` + collection + `", tokenId: "1") { tokenId owner } }`})
+			return status(r, err)
+		},
+	}
+	expected := map[string]int{"unknown nft": http.StatusNotFound, "refreshNftMetadata": http.StatusAccepted}
+	for name, call := range statuses {
+		code, err := call()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		want, ok := expected[name]
+		if !ok {
+			want = http.StatusOK
+		}
+		if code != want {
+			t.Errorf("%s answered %d, expected %d", name, code, want)
+		}
+	}
+
+	// The streams never end, their error responses are checked
+	tokenId := "1"
+	_, err = api.StreamTransfersWithResponse(ctx, &client.StreamTransfersParams{TokenId: &tokenId})
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = api.StreamTransfersWebSocketWithResponse(ctx, &client.StreamTransfersWebSocketParams{TokenId: &tokenId})
+	if err != nil {
+		t.Error(err)
+	}
+
+	url := receiver.URL
+	created, err := api.CreateWebhookWithResponse(ctx, nil, client.WebhookCreate{Url: url})
+	if err != nil || created.JSON201 == nil {
+		t.Fatalf("webhook not created: %v", err)
+	}
+	id := created.JSON201.Data.Id
+	for name, call := range map[string]func() (int, error){
+		"getWebhooks": func() (int, error) { r, err := api.GetWebhooksWithResponse(ctx, nil); return status(r, err) },
+		"getWebhook":  func() (int, error) { r, err := api.GetWebhookWithResponse(ctx, id); return status(r, err) },
+		"testWebhook": func() (int, error) { r, err := api.TestWebhookWithResponse(ctx, id); return status(r, err) },
+		"getWebhookDeliveries": func() (int, error) {
+			r, err := api.GetWebhookDeliveriesWithResponse(ctx, id, nil)
+			return status(r, err)
+		},
+		"disableWebhook": func() (int, error) { r, err := api.DisableWebhookWithResponse(ctx, id); return status(r, err) },
+	} {
+		code, err := call()
+		if err != nil || code != http.StatusOK {
+			t.Errorf("%s answered %d: %v", name, code, err)
+		}
+	}
+
+	for _, path := range document.Paths.InMatchingOrder() {
+		for _, operation := range document.Paths.Find(path).Operations() {
+			if !doer.called[operation.OperationID] {
+				t.Errorf("%s is never called", operation.OperationID)
+			}
+		}
+	}
+}
+
+// Status of a response of the generated client
+func status(response interface{ StatusCode() int }, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	return response.StatusCode(), nil
+}
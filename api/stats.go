@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Windows of /collection/stats, they are summed from the hourly buckets except all that uses the daily ones
+var statsWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"all": 0,
+}
+
+// Bucket sizes of the series, in seconds
+var statsPeriods = map[string]int64{"hour": 3600, "day": 86400}
+
+// Most points returned by a series
+const maxSeriesPoints = 1000
+
+func getCollectionStats(c *gin.Context) {
+	collection, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	name := c.DefaultQuery("window", "24h")
+	duration, known := statsWindows[name]
+	if !known {
+		invalidParameter(c, "invalid window "+strconv.Quote(name)+", expected 1h, 24h, 7d, 30d or all")
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+
+	// The window starts at the beginning of the hour it falls in
+	period := "hour"
+	var since int64
+	if duration == 0 {
+		period = "day"
+	} else {
+		since = time.Now().Add(-duration).Unix()
+		since -= since % 3600
+	}
+
+	window, err := store.SelectCollectionWindow(c.Request.Context(), chainId, collection, period, since)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+	window.Window = name
+
+	c.JSON(http.StatusOK, gin.H{"data": window})
+}
+
+// Stats of a collection for every hour or day between from and to (unix timestamps), the buckets without activity are included
+func getCollectionStatsSeries(c *gin.Context) {
+	collection, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	period := c.DefaultQuery("period", "day")
+	size, known := statsPeriods[period]
+	if !known {
+		invalidParameter(c, "invalid period "+strconv.Quote(period)+", expected hour or day")
+		return
+	}
+	to, ok := timestampQuery(c, "to", time.Now().Unix())
+	if !ok {
+		return
+	}
+	from, ok := timestampQuery(c, "from", to-30*size)
+	if !ok {
+		return
+	}
+	from -= from % size
+	to -= to % size
+	if from > to || (to-from)/size >= maxSeriesPoints {
+		invalidParameter(c, "invalid range, from must be before to and the series can not have more than "+strconv.Itoa(maxSeriesPoints)+" points")
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+
+	points, err := store.SelectStatsSeries(c.Request.Context(), chainId, collection, period, size, from, to)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": points})
+}
+
+func timestampQuery(c *gin.Context, name string, fallback int64) (int64, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return fallback, true
+	}
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || timestamp < 0 {
+		invalidParameter(c, "invalid "+name+" "+strconv.Quote(value)+", expected a unix timestamp")
+		return 0, false
+	}
+	return timestamp, true
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"workspace/customTypes"
+	"workspace/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Events read at once by a stream, a full batch is followed by the next one without waiting
+const streamBatchSize = 100
+
+// Keeps the idle connections open through the proxies and detects the clients that left
+const streamHeartbeat = 25 * time.Second
+
+// Wakes the streams when the indexer writes blocks and closes them on shutdown
+var streams = newStreamHub()
+
+type streamHub struct {
+	mu sync.Mutex
+	// Closed and replaced every time the indexer may have written blocks
+	changed     chan struct{}
+	closed      chan struct{}
+	closeOnce   sync.Once
+	subscribers int
+}
+
+func newStreamHub() *streamHub {
+	return &streamHub{changed: make(chan struct{}), closed: make(chan struct{})}
+}
+
+// Wake the streams on every notification of the database
+func (h *streamHub) run(notifications <-chan struct{}) {
+	for range notifications {
+		h.mu.Lock()
+		close(h.changed)
+		h.changed = make(chan struct{})
+		h.mu.Unlock()
+	}
+}
+
+// Channel closed on the next notification, taken before a read so that the blocks written during it wake the stream again
+func (h *streamHub) next() <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.changed
+}
+
+// Count a new stream, false when stream_max_subscribers are already open
+func (h *streamHub) join() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers >= cfg.StreamMaxSubscribers {
+		return false
+	}
+	h.subscribers++
+	return true
+}
+
+func (h *streamHub) leave() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers--
+}
+
+// End every stream, the server does not wait for them on shutdown
+func (h *streamHub) close() {
+	h.closeOnce.Do(func() { close(h.closed) })
+}
+
+// Event sent to the clients, the cursor resumes the stream after it
+type streamMessage struct {
+	Type     string                      `json:"type"`
+	Cursor   string                      `json:"cursor"`
+	Transfer *customTypes.ERC721TxStruct `json:"transfer,omitempty"`
+	// Last canonical block of a rollback, the transfers sent after it are no longer valid
+	Block *uint64 `json:"block,omitempty"`
+}
+
+func newStreamMessage(event database.StreamEvent) streamMessage {
+	if event.Rollback != nil {
+		return streamMessage{Type: "rollback", Cursor: event.Cursor, Block: event.Rollback}
+	}
+	return streamMessage{Type: "transfer", Cursor: event.Cursor, Transfer: event.Transfer}
+}
+
+// Transfers of a subscription from its cursor
+type transferStream struct {
+	filter database.TransferFilter
+	// Cursor the stream started from
+	start string
+	// Cursor after the last read event
+	cursor string
+	// Read and not sent yet
+	events []database.StreamEvent
+	// Closed when the next events may be indexed
+	changed <-chan struct{}
+}
+
+// Read the filter of a stream from the query parameters and its first events. The error response is sent on failure,
+// leave must be called once the stream is over otherwise
+func openTransferStream(c *gin.Context, cursor string) (*transferStream, bool) {
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return nil, false
+	}
+	collection, ok := addressQuery(c, "collection")
+	if !ok {
+		return nil, false
+	}
+	address, ok := addressQuery(c, "address")
+	if !ok {
+		return nil, false
+	}
+	tokenId, ok := tokenIdQuery(c)
+	if !ok {
+		return nil, false
+	}
+	if tokenId != "" && collection == "" {
+		invalidParameter(c, "tokenId needs a collection")
+		return nil, false
+	}
+	tag := c.Query("tag")
+	if tag != "" && tag != "mint" && tag != "transfer" && tag != "burn" {
+		invalidParameter(c, "invalid tag "+strconv.Quote(tag)+", expected mint, transfer or burn")
+		return nil, false
+	}
+
+	if !streams.join() {
+		abortWithError(c, http.StatusServiceUnavailable, codeUnavailable, "too many open streams")
+		return nil, false
+	}
+	stream := &transferStream{
+		filter: database.TransferFilter{ChainId: chainId, Collection: collection, TokenId: tokenId, Address: address, Tag: tag},
+		cursor: cursor,
+	}
+	err := stream.read(c.Request.Context())
+	if err != nil {
+		streams.leave()
+		if errors.Is(err, database.ErrInvalidCursor) {
+			abortWithError(c, http.StatusBadRequest, codeInvalidCursor, "invalid cursor, expected the cursor of an event of the stream")
+		} else {
+			internalError(c, err)
+		}
+		return nil, false
+	}
+	// Without a cursor the stream starts at the position of the first read
+	stream.start = cursor
+	if cursor == "" {
+		stream.start = stream.cursor
+	}
+	return stream, true
+}
+
+// Read the events after the cursor and move it after them
+func (s *transferStream) read(ctx context.Context) (err error) {
+	s.changed = streams.next()
+	ctx, cancel := context.WithTimeout(ctx, cfg.RequestTimeout)
+	defer cancel()
+	s.events, s.cursor, err = store.SelectTransferStream(ctx, s.filter, s.cursor, streamBatchSize)
+	return err
+}
+
+// Send the events as they are indexed until ctx is done, sending fails or the server shuts down
+func (s *transferStream) run(ctx context.Context, send func(message streamMessage) error, ping func() error) error {
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		for _, event := range s.events {
+			err := send(newStreamMessage(event))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(s.events) < streamBatchSize {
+		wait:
+			for {
+				select {
+				case <-s.changed:
+					break wait
+				case <-heartbeat.C:
+					err := ping()
+					if err != nil {
+						return err
+					}
+				case <-ctx.Done():
+					return nil
+				case <-streams.closed:
+					return nil
+				}
+			}
+		}
+
+		err := s.read(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Server-Sent Events, the id of an event is its cursor so that EventSource resumes the stream after a reconnection
+func streamTransfers(c *gin.Context) {
+	cursor := c.GetHeader("Last-Event-ID")
+	if cursor == "" {
+		cursor = c.Query("cursor")
+	}
+	stream, ok := openTransferStream(c, cursor)
+	if !ok {
+		return
+	}
+	defer streams.leave()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	// The first event gives the cursor of the start of the stream
+	fmt.Fprintf(c.Writer, "id: %s\nevent: ready\ndata: {}\n\n", stream.start)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	err := stream.run(ctx, func(message streamMessage) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", message.Cursor, message.Type, data)
+		c.Writer.Flush()
+		return err
+	}, func() error {
+		_, err := fmt.Fprint(c.Writer, ": ping\n\n")
+		c.Writer.Flush()
+		return err
+	})
+	// Writing fails once the client left
+	if err != nil && ctx.Err() == nil {
+		log.Println("Transfer stream:", err)
+	}
+}
+
+var upgrader = websocket.Upgrader{
+	// Browsers are held to the origins of cors_origins like for the other routes
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range cfg.CORSOrigins {
+			if allowed == "*" || allowed == origin {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// WebSocket stream, every message is a JSON event. The client reconnects with the cursor of the last one it received
+func streamTransfersWebSocket(c *gin.Context) {
+	stream, ok := openTransferStream(c, c.Query("cursor"))
+	if !ok {
+		return
+	}
+	defer streams.leave()
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader replied with the error
+		return
+	}
+	defer conn.Close()
+
+	// Messages of the client are ignored, reading handles the pongs and notices when it leaves
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(2 * streamHeartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * streamHeartbeat))
+	})
+	go func() {
+		defer cancel()
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(message streamMessage) error {
+		conn.SetWriteDeadline(time.Now().Add(cfg.RequestTimeout))
+		return conn.WriteJSON(message)
+	}
+	err = send(streamMessage{Type: "ready", Cursor: stream.start})
+	if err == nil {
+		err = stream.run(ctx, send, func() error {
+			return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(cfg.RequestTimeout))
+		})
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Println("Transfer stream:", err)
+		return
+	}
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(time.Second))
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Values of the trait[<type>] query parameters by trait type
+func traitFilters(c *gin.Context) map[string][]string {
+	filters := map[string][]string{}
+	for key, values := range c.Request.URL.Query() {
+		if strings.HasPrefix(key, "trait[") && strings.HasSuffix(key, "]") {
+			traitType := key[len("trait[") : len(key)-1]
+			filters[traitType] = append(filters[traitType], values...)
+		}
+	}
+	return filters
+}
+
+// List the traits of a collection with the number of NFTs having each of them
+func getCollectionTraits(c *gin.Context) {
+	collection, ok := addressParam(c, "addr")
+	if !ok {
+		return
+	}
+	chainId, ok := selectedChain(c)
+	if !ok {
+		return
+	}
+
+	traits, err := store.SelectTraitCounts(c.Request.Context(), chainId, collection)
+	if err != nil {
+		internalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": traits})
+}
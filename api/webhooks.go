@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"workspace/customTypes"
+	"workspace/database"
+)
+
+// Delay before looking for due retries when nothing was indexed
+const webhookPollInterval = time.Second
+
+// Events queued for a webhook at once
+const webhookBatchSize = 100
+
+// Deliveries claimed by a worker at once, they are leased for long enough to be sent one after the other
+const webhookClaimsPerWorker = 16
+
+var webhookLog = log.New(os.Stderr, "[webhooks] ", log.LstdFlags)
+
+// Redirects are not followed, a delivery succeeds with a 2xx answer of its url
+var webhookClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+// Body of a delivery: an event of the transfer stream of the webhook, or a test
+type webhookEvent struct {
+	WebhookId int64 `json:"webhookId"`
+	streamMessage
+}
+
+// Queue the events of the active webhooks and send the due deliveries until ctx is done
+func runWebhooks(ctx context.Context) {
+	for {
+		// Taken before the reads so that the blocks indexed during them are seen by the next loop
+		changed := streams.next()
+		busy := queueWebhookEvents(ctx)
+		lease := webhookClaimsPerWorker*cfg.WebhookTimeout + time.Minute
+		tasks, err := store.ClaimDueWebhookDeliveries(ctx, webhookClaimsPerWorker*cfg.WebhookWorkers, lease)
+		if err != nil {
+			webhookLog.Println("Claim due deliveries :", err)
+		}
+		if len(tasks) > 0 {
+			sendDeliveries(ctx, tasks)
+			busy = true
+		}
+		if busy {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		case <-time.After(webhookPollInterval):
+		}
+	}
+}
+
+// Queue the events indexed since the cursor of every active webhook, busy is true when more are waiting
+func queueWebhookEvents(ctx context.Context) (busy bool) {
+	webhooks, err := store.SelectActiveWebhooks(ctx)
+	if err != nil {
+		webhookLog.Println("Select webhooks :", err)
+		return false
+	}
+	for _, webhook := range webhooks {
+		events, next, err := store.SelectTransferStream(ctx, database.WebhookFilter(webhook), webhook.Cursor, webhookBatchSize)
+		if err != nil {
+			webhookLog.Println("Read the events of webhook", webhook.Id, ":", err)
+			continue
+		}
+		if next == webhook.Cursor {
+			continue
+		}
+
+		deliveries := make([]customTypes.WebhookDeliveryStruct, len(events))
+		for i, event := range events {
+			message := newStreamMessage(event)
+			payload, err := json.Marshal(webhookEvent{WebhookId: webhook.Id, streamMessage: message})
+			if err != nil {
+				webhookLog.Println("Encode an event of webhook", webhook.Id, ":", err)
+				return false
+			}
+			deliveries[i] = customTypes.WebhookDeliveryStruct{Event: message.Type, Payload: payload}
+		}
+		_, err = store.QueueWebhookDeliveries(ctx, webhook, next, deliveries)
+		if err != nil {
+			webhookLog.Println("Queue the events of webhook", webhook.Id, ":", err)
+			continue
+		}
+		if len(events) == webhookBatchSize {
+			busy = true
+		}
+	}
+	return busy
+}
+
+func sendDeliveries(ctx context.Context, tasks []customTypes.WebhookTask) {
+	queue := make(chan customTypes.WebhookTask)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.WebhookWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				_, err := deliver(ctx, task, true)
+				if err != nil && ctx.Err() == nil {
+					webhookLog.Println("Record delivery", task.Id, ":", err)
+				}
+			}
+		}()
+	}
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// Send a delivery and record the attempt. A failed delivery is retried with an exponential backoff until
+// webhook_max_attempts when retry is true, it fails at once otherwise. An attempt cut by ctx is not recorded
+func deliver(ctx context.Context, task customTypes.WebhookTask, retry bool) (attempt customTypes.WebhookAttemptStruct, err error) {
+	start := time.Now()
+	attempt = customTypes.WebhookAttemptStruct{AttemptedAt: start.Unix()}
+	statusCode, err := post(ctx, task, start)
+	if ctx.Err() != nil {
+		return attempt, ctx.Err()
+	}
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+
+	status := "delivered"
+	nextAttempt := start
+	if err != nil {
+		attempt.Error = err.Error()
+		status = "pending"
+		if !retry || task.Attempts+1 >= cfg.WebhookMaxAttempts {
+			status = "failed"
+		}
+		delay := cfg.WebhookRetryDelay << task.Attempts
+		if delay <= 0 || delay > 24*time.Hour {
+			delay = 24 * time.Hour
+		}
+		nextAttempt = start.Add(delay)
+	}
+	// Recorded even if ctx ends now, the request was sent
+	recordCtx, cancel := context.WithTimeout(context.Background(), cfg.RequestTimeout)
+	defer cancel()
+	return attempt, store.InsertWebhookAttempt(recordCtx, task, attempt, status, nextAttempt)
+}
+
+// POST the payload signed with the secret of the webhook, statusCode is 0 without a response
+func post(ctx context.Context, task customTypes.WebhookTask, now time.Time) (statusCode int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.WebhookTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, task.URL, bytes.NewReader(task.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "segment-webhooks")
+	request.Header.Set("X-Segment-Delivery", strconv.FormatInt(task.Id, 10))
+	request.Header.Set("X-Segment-Event", task.Event)
+	request.Header.Set("X-Segment-Timestamp", timestamp)
+	request.Header.Set("X-Segment-Signature", "sha256="+signature(task.Secret, timestamp, task.Payload))
+
+	response, err := webhookClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// Read a little of the body so that the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, errors.New("unexpected status " + response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// Hex HMAC-SHA256 of "<timestamp>.<body>", the timestamp lets the receivers reject the replayed deliveries
+func signature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"workspace/customTypes"
+	"workspace/database"
+	"workspace/database/dbtest"
+)
+
+const testSecret = "webhook-secret"
+
+// Create a webhook sending to url, index the seeded blocks and claim the deliveries queued for them
+func claimSeededDeliveries(t *testing.T, url string) (customTypes.WebhookStruct, []customTypes.WebhookTask) {
+	t.Helper()
+	ctx := context.Background()
+	webhook, err := store.InsertWebhook(ctx, customTypes.WebhookStruct{ChainId: dbtest.Chain, URL: url, Secret: testSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed(t)
+	queueWebhookEvents(ctx)
+	tasks, err := store.ClaimDueWebhookDeliveries(ctx, 100, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) == 0 {
+		t.Fatal("no delivery queued for the seeded blocks")
+	}
+	return webhook, tasks
+}
+
+// Delivery of a webhook with its attempts
+func findDelivery(t *testing.T, webhookId int64, id int64) customTypes.WebhookDeliveryStruct {
+	t.Helper()
+	deliveries, _, err := store.SelectWebhookDeliveries(context.Background(), webhookId, database.Page{Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, delivery := range deliveries {
+		if delivery.Id == id {
+			return delivery
+		}
+	}
+	t.Fatalf("delivery %d not found", id)
+	return customTypes.WebhookDeliveryStruct{}
+}
+
+// The deliveries are signed over "<timestamp>.<body>" and done once their url answers a 2xx
+func TestWebhookDeliveries(t *testing.T) {
+	startAPI(t)
+	var mu sync.Mutex
+	received := map[string]string{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write([]byte(r.Header.Get("X-Segment-Timestamp") + "." + string(body)))
+		if r.Header.Get("X-Segment-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("delivery %s: wrong signature %q", r.Header.Get("X-Segment-Delivery"), r.Header.Get("X-Segment-Signature"))
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get("X-Segment-Timestamp"), 10, 64)
+		if err != nil || time.Since(time.Unix(timestamp, 0)) > time.Minute {
+			t.Errorf("timestamp %q", r.Header.Get("X-Segment-Timestamp"))
+		}
+		mu.Lock()
+		received[r.Header.Get("X-Segment-Delivery")] = r.Header.Get("X-Segment-Event")
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(receiver.Close)
+
+	webhook, tasks := claimSeededDeliveries(t, receiver.URL)
+	sendDeliveries(context.Background(), tasks)
+
+	if len(received) != len(tasks) {
+		t.Fatalf("%d deliveries received, %d sent", len(received), len(tasks))
+	}
+	for _, task := range tasks {
+		if received[strconv.FormatInt(task.Id, 10)] != task.Event {
+			t.Errorf("delivery %d received as %q, expected %q", task.Id, received[strconv.FormatInt(task.Id, 10)], task.Event)
+		}
+		delivery := findDelivery(t, webhook.Id, task.Id)
+		if delivery.Status != "delivered" || delivery.Attempts != 1 || len(delivery.History) != 1 {
+			t.Fatalf("delivery %+v", delivery)
+		}
+		if code := delivery.History[0].StatusCode; code == nil || *code != http.StatusNoContent || delivery.History[0].Error != "" {
+			t.Errorf("attempt %+v", delivery.History[0])
+		}
+	}
+}
+
+// A failed delivery is retried later, after a delay doubling with its attempts, and fails at the last one
+func TestWebhookRetries(t *testing.T) {
+	startAPI(t)
+	cfg.WebhookRetryDelay = time.Minute
+	cfg.WebhookMaxAttempts = 3
+	cfg.WebhookTimeout = 100 * time.Millisecond
+	var timeout atomic.Bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if timeout.Load() {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(receiver.Close)
+	webhook, tasks := claimSeededDeliveries(t, receiver.URL)
+	task := tasks[0]
+
+	for attempts, delay := range []time.Duration{time.Minute, 2 * time.Minute} {
+		task.Attempts = attempts
+		timeout.Store(attempts == 1)
+		before := time.Now()
+		_, err := deliver(context.Background(), task, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		delivery := findDelivery(t, webhook.Id, task.Id)
+		if delivery.Status != "pending" || delivery.Attempts != attempts+1 || len(delivery.History) != attempts+1 {
+			t.Fatalf("after %d failures: %+v", attempts+1, delivery)
+		}
+		if delivery.NextAttempt < before.Add(delay).Unix() || delivery.NextAttempt > time.Now().Add(delay).Unix() {
+			t.Errorf("after %d failures the next attempt is in %ds, expected %s", attempts+1, delivery.NextAttempt-before.Unix(), delay)
+		}
+		last := delivery.History[attempts]
+		if timeout.Load() && (last.StatusCode != nil || last.Error == "") {
+			t.Errorf("timed out attempt %+v", last)
+		}
+		if !timeout.Load() && (last.StatusCode == nil || *last.StatusCode != http.StatusServiceUnavailable || last.Error == "") {
+			t.Errorf("failed attempt %+v", last)
+		}
+	}
+
+	timeout.Store(false)
+	task.Attempts = cfg.WebhookMaxAttempts - 1
+	_, err := deliver(context.Background(), task, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delivery := findDelivery(t, webhook.Id, task.Id)
+	if delivery.Status != "failed" || delivery.Attempts != cfg.WebhookMaxAttempts {
+		t.Errorf("after the last attempt: %+v", delivery)
+	}
+}
+
+// A claimed delivery is skipped by the other workers until its lease ends
+func TestWebhookClaims(t *testing.T) {
+	startAPI(t)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(receiver.Close)
+	ctx := context.Background()
+	_, tasks := claimSeededDeliveries(t, receiver.URL)
+
+	claimed, err := store.ClaimDueWebhookDeliveries(ctx, 100, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 0 {
+		t.Errorf("%d leased deliveries claimed again", len(claimed))
+	}
+
+	// A failed attempt makes the delivery due again, it is claimed again once the lease of its next claim has ended
+	err = store.InsertWebhookAttempt(ctx, tasks[0], customTypes.WebhookAttemptStruct{AttemptedAt: time.Now().Unix(), Error: "cut"}, "pending", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed, err = store.ClaimDueWebhookDeliveries(ctx, 100, -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0].Id != tasks[0].Id {
+		t.Fatalf("claimed %+v, expected delivery %d", claimed, tasks[0].Id)
+	}
+	claimed, err = store.ClaimDueWebhookDeliveries(ctx, 100, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0].Id != tasks[0].Id {
+		t.Errorf("claimed %+v after the end of the lease, expected delivery %d", claimed, tasks[0].Id)
+	}
+}
+
+// A delivery cut by the shutdown is not recorded, it is sent again once its lease ends
+func TestWebhookDeliveryCutByShutdown(t *testing.T) {
+	startAPI(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cut := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-cut
+	}))
+	t.Cleanup(receiver.Close)
+	webhook, tasks := claimSeededDeliveries(t, receiver.URL)
+
+	_, err := deliver(ctx, tasks[0], true)
+	close(cut)
+	if err != context.Canceled {
+		t.Errorf("delivery cut with %v", err)
+	}
+	delivery := findDelivery(t, webhook.Id, tasks[0].Id)
+	if delivery.Status != "pending" || delivery.Attempts != 0 || len(delivery.History) != 0 {
+		t.Errorf("cut delivery %+v", delivery)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"workspace/database"
+)
+
+const usage = `Usage: indexer [flags] [command]
+
+The flags override the configuration file given with -config and the INDEXER_* environment variables, see -help.
+
+  indexer                     Apply pending migrations and start indexing
+  indexer migrate up          Apply every pending migration
+  indexer migrate down [n]    Revert the last n migrations (default 1)
+  indexer migrate status      List the migrations and whether they are applied
+  indexer reset               Delete every indexed row and recreate the schema
+  indexer stats rebuild       Recompute the collection stats from the indexed rows`
+
+// Run a maintenance command, returns false if args do not contain one
+func runCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	store, err := database.OpenDatabase(cfg.DatabaseDriver, cfg.DatabaseURL, cfg.DBConnections)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	switch {
+	case args[0] == "migrate" && len(args) == 2 && args[1] == "up":
+		applied, err := store.MigrateUp(ctx)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		log.Println("Applied", applied, "migrations")
+
+	case args[0] == "migrate" && len(args) >= 2 && len(args) <= 3 && args[1] == "down":
+		steps := 1
+		if len(args) == 3 {
+			steps, err = strconv.Atoi(args[2])
+			if err != nil || steps < 1 {
+				log.Fatalln("Invalid number of migrations :", args[2])
+			}
+		}
+		reverted, err := store.MigrateDown(ctx, steps)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		log.Println("Reverted", reverted, "migrations")
+
+	case args[0] == "migrate" && len(args) == 2 && args[1] == "status":
+		status, err := store.SelectMigrations(ctx)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, migration := range status {
+			state := "pending"
+			if migration.Applied {
+				state = "applied " + migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d %-30s %s\n", migration.Version, migration.Name, state)
+		}
+
+	case args[0] == "reset" && len(args) == 1:
+		err := store.ResetDatabase(ctx)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		log.Println("Database reset")
+
+	case args[0] == "stats" && len(args) == 2 && args[1] == "rebuild":
+		err := store.RebuildStats(ctx)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		log.Println("Collection stats rebuilt")
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	return true
+}
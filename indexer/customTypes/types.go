@@ -1,10 +1,13 @@
 package customTypes
 
 import (
+	"encoding/json"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type ERC721CollectionStruct struct {
+	ChainId           uint64
 	ContractAddress   common.Address
 	ContractName      string
 	ContractSymbol    string
@@ -14,23 +17,291 @@ type ERC721CollectionStruct struct {
 }
 
 type ERC721TxStruct struct {
-	Timestamp   uint64
+	ChainId     uint64         `json:"chainId"`
+	Timestamp   uint64         `json:"timestamp"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      string         `json:"txHash"`
+	LogIndex    uint           `json:"logIndex"`
+	Tag         string         `json:"tag"`
+	FromAddr    common.Address `json:"from"`
+	ToAddr      common.Address `json:"to"`
+	TokenId     string         `json:"tokenId"`
+	Collection  common.Address `json:"collection"`
+	// Price of the token inferred from the payments of the transaction, empty when none was found
+	Price       string         `json:"price,omitempty"`
+	Currency    common.Address `json:"currency"`
+	PriceSource string         `json:"priceSource,omitempty"`
+	Confidence  string         `json:"confidence,omitempty"`
+}
+
+type ERC721Struct struct {
+	ChainId         uint64         `json:"chainId"`
+	MintTimestamp   uint64         `json:"mintTimestamp"`
+	MintBlockNumber uint64         `json:"mintBlockNumber"`
+	MintTxHash      string         `json:"mintTxHash"`
+	URI             string         `json:"uri"`
+	TokenId         string         `json:"tokenId"`
+	Collection      common.Address `json:"collection"`
+	Owner           common.Address `json:"owner"`
+}
+
+type ERC1155CollectionStruct struct {
+	ChainId           uint64
+	ContractAddress   common.Address
+	ContractName      string
+	ContractSymbol    string
+	DeployTimestamp   uint64
+	DeployBlockNumber uint64
+	DeployTxHash      string
+}
+
+// One transferred id, a TransferBatch gives one per id
+type ERC1155TxStruct struct {
+	ChainId     uint64         `json:"chainId"`
+	Timestamp   uint64         `json:"timestamp"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      string         `json:"txHash"`
+	LogIndex    uint           `json:"logIndex"`
+	BatchIndex  int            `json:"batchIndex"`
+	Tag         string         `json:"tag"`
+	Operator    common.Address `json:"operator"`
+	FromAddr    common.Address `json:"from"`
+	ToAddr      common.Address `json:"to"`
+	TokenId     string         `json:"tokenId"`
+	Amount      string         `json:"amount"`
+	Collection  common.Address `json:"collection"`
+	// Price of one token of the transfer inferred from the payments of the transaction, empty when none was found
+	Price       string         `json:"price,omitempty"`
+	Currency    common.Address `json:"currency"`
+	PriceSource string         `json:"priceSource,omitempty"`
+	Confidence  string         `json:"confidence,omitempty"`
+}
+
+type ERC1155TokenStruct struct {
+	ChainId         uint64         `json:"chainId"`
+	MintTimestamp   uint64         `json:"mintTimestamp"`
+	MintBlockNumber uint64         `json:"mintBlockNumber"`
+	MintTxHash      string         `json:"mintTxHash"`
+	URI             string         `json:"uri"`
+	TokenId         string         `json:"tokenId"`
+	Collection      common.Address `json:"collection"`
+}
+
+type ERC1155UriStruct struct {
+	ChainId     uint64
 	BlockNumber uint64
 	TxHash      string
-	Tag         string
-	FromAddr    common.Address
-	ToAddr      common.Address
-	Value       string
+	LogIndex    uint
 	TokenId     string
 	Collection  common.Address
+	URI         string
 }
 
-type ERC721Struct struct {
-	MintTimestamp   uint64
-	MintBlockNumber uint64
-	MintTxHash      string
-	URI             string
-	TokenId         string
-	Collection      common.Address
-	Owner           common.Address
+// Tokens bought in a marketplace order, Currency is the zero address for the native currency
+type SaleStruct struct {
+	ChainId     uint64
+	Timestamp   uint64
+	BlockNumber uint64
+	TxHash      string
+	LogIndex    uint
+	ItemIndex   int
+	Marketplace string
+	Collection  common.Address
+	TokenId     string
+	Amount      string
+	Seller      common.Address
+	Buyer       common.Address
+	Currency    common.Address
+	Price       string
+}
+
+type BlockStruct struct {
+	ChainId    uint64
+	Number     uint64
+	Hash       string
+	ParentHash string
+	Timestamp  uint64
+}
+
+// Everything written to the database for one block
+type BlockData struct {
+	Block       BlockStruct
+	Collections []ERC721CollectionStruct
+	Mints       []ERC721Struct
+	Txs         []ERC721TxStruct
+
+	ERC1155Collections []ERC1155CollectionStruct
+	ERC1155Tokens      []ERC1155TokenStruct
+	ERC1155Txs         []ERC1155TxStruct
+	ERC1155Uris        []ERC1155UriStruct
+
+	Sales []SaleStruct
+}
+
+// Token whose metadata must be fetched
+type MetadataTask struct {
+	ChainId    uint64
+	Collection string
+	TokenId    string
+	URI        string
+	// Failed attempts with this uri
+	Attempts int
+}
+
+// Parsed metadata document of a token
+type MetadataStruct struct {
+	Name        string
+	Description string
+	// Image URL, ipfs:// and ar:// images are rewritten to the first gateway
+	Image      string
+	Attributes json.RawMessage
+	// Attributes with a text value, numeric ones are left out of the traits
+	Traits []TraitStruct
+	// Whole document as fetched
+	Document json.RawMessage
+}
+
+type TraitStruct struct {
+	TraitType string `json:"traitType"`
+	Value     string `json:"value"`
+}
+
+// ///////////////////////////////////// READS ///////////////////////////////////////
+// Off-chain metadata of an NFT as stored, Status is pending, done or failed
+type NFTMetadataStruct struct {
+	Status      string          `json:"status"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Image       string          `json:"image"`
+	Attributes  json.RawMessage `json:"attributes"`
+	FetchedAt   *uint64         `json:"fetchedAt"`
+	LastError   string          `json:"lastError"`
+}
+
+// Rarity of an NFT in its collection, rank 1 is the rarest
+type RarityStruct struct {
+	Score float64 `json:"score"`
+	Rank  int64   `json:"rank"`
+}
+
+type NFTStruct struct {
+	ERC721Struct
+	// Only returned for a single NFT, nil until the indexer fetched the document
+	Metadata *NFTMetadataStruct `json:"metadata,omitempty"`
+	// Nil until the metadata of the collection is fetched
+	Rarity *RarityStruct `json:"rarity,omitempty"`
+}
+
+// Number of NFTs of a collection having a trait
+type TraitCountStruct struct {
+	TraitStruct
+	Count int64 `json:"count"`
+}
+
+type ERC1155SupplyStruct struct {
+	ERC1155TokenStruct
+	// Sum of the balances of the holders
+	Supply string `json:"supply"`
+}
+
+type ERC1155BalanceStruct struct {
+	ChainId    uint64         `json:"chainId"`
+	TokenId    string         `json:"tokenId"`
+	Collection common.Address `json:"collection"`
+	Holder     common.Address `json:"holder"`
+	Balance    string         `json:"balance"`
+	URI        string         `json:"uri"`
+}
+
+type ERC1155CollectionStatsStruct struct {
+	OwnerCount int `json:"ownerCount"`
+	TokenCount int `json:"tokenCount"`
+	// Transactions of the last 24 hours
+	TxCount int `json:"txCount"`
+	// ERC-1155 sales are not priced yet
+	Volume int `json:"volume"`
+}
+
+// Activity of a collection over a window or a bucket. Volume, floor and average price are in the native currency,
+// floor and average price are null without sales and holders when it is not known
+type CollectionStatsStruct struct {
+	Transfers    int     `json:"transfers"`
+	Mints        int     `json:"mints"`
+	Burns        int     `json:"burns"`
+	SaleCount    int     `json:"saleCount"`
+	Buyers       int     `json:"buyers"`
+	Sellers      int     `json:"sellers"`
+	Holders      *int    `json:"holders"`
+	Volume       string  `json:"volume"`
+	Floor        *string `json:"floor"`
+	AveragePrice *string `json:"averagePrice"`
+}
+
+type StatsPointStruct struct {
+	Bucket int64 `json:"bucket"`
+	CollectionStatsStruct
+}
+
+// Sales of a collection in one currency, amounts are in the smallest unit of the currency
+type SaleStatsStruct struct {
+	Currency  string `json:"currency"`
+	SaleCount int    `json:"saleCount"`
+	Volume    string `json:"volume"`
+	// Lowest and average price of a token, null without sales
+	Floor        *string `json:"floor"`
+	AveragePrice *string `json:"averagePrice"`
+}
+
+// Activity of a collection since the start of a window, Window is the name it was requested with
+type CollectionWindowStruct struct {
+	Window     string `json:"window"`
+	OwnerCount int    `json:"ownerCount"`
+	TxCount    int    `json:"txCount"`
+	CollectionStatsStruct
+	// Every currency is listed apart
+	Currencies []SaleStatsStruct `json:"currencies"`
+}
+
+// ///////////////////////////////////// WEBHOOKS ///////////////////////////////////////
+// Outbound webhook, the empty filters match every transfer. The secret is only returned when the webhook is created
+type WebhookStruct struct {
+	Id         int64  `json:"id"`
+	ChainId    uint64 `json:"chainId"`
+	URL        string `json:"url"`
+	Collection string `json:"collection,omitempty"`
+	Address    string `json:"address,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+	Active     bool   `json:"active"`
+	CreatedAt  int64  `json:"createdAt"`
+	// Position of the webhook in the transfer stream
+	Cursor string `json:"-"`
+}
+
+// Event sent to a webhook, Status is pending until it is delivered or failed after the last attempt
+type WebhookDeliveryStruct struct {
+	Id          int64                  `json:"id"`
+	WebhookId   int64                  `json:"webhookId"`
+	Event       string                 `json:"event"`
+	Payload     json.RawMessage        `json:"payload"`
+	Status      string                 `json:"status"`
+	Attempts    int                    `json:"attempts"`
+	NextAttempt int64                  `json:"nextAttempt"`
+	CreatedAt   int64                  `json:"createdAt"`
+	History     []WebhookAttemptStruct `json:"history"`
+}
+
+// Request sent for a delivery, StatusCode is nil when no response was received
+type WebhookAttemptStruct struct {
+	AttemptedAt int64  `json:"attemptedAt"`
+	StatusCode  *int   `json:"statusCode"`
+	Error       string `json:"error,omitempty"`
+	DurationMs  int64  `json:"durationMs"`
+}
+
+// Delivery to send with the address and secret of its webhook
+type WebhookTask struct {
+	WebhookDeliveryStruct
+	URL    string
+	Secret string
 }
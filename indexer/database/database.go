@@ -1,31 +1,126 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"strings"
 
-	"workspace/config"
 	"workspace/customTypes"
-	"workspace/database/dto"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// Do not log the failed writes, they are returned to the caller anyway
+var IgnoreErr = true
+
 // ///////////////////////////////////// QUERIES ///////////////////////////////////////
+// Write everything found in a block and advance the checkpoint in a single transaction.
+// Every write is idempotent so replaying a block is always safe.
+func (s *sqlStore) ApplyBlock(ctx context.Context, data customTypes.BlockData) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		err := w.insertBlockData(data)
+		if err != nil {
+			return err
+		}
+		err = w.advanceCheckpoint(data.Block.ChainId)
+		if err != nil {
+			return err
+		}
+		return w.notifyBlocks(data.Block.ChainId)
+	})
+}
+
+// Write the blocks of a scanned range in a single transaction and move the checkpoint to its last block.
+// Only the blocks with activity are given, last is recorded so that the range is not scanned again.
+func (s *sqlStore) ApplyRange(ctx context.Context, blocks []customTypes.BlockData, last customTypes.BlockStruct) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		for _, data := range blocks {
+			err := w.insertBlockData(data)
+			if err != nil {
+				return err
+			}
+		}
+		err := w.insertBlock(last)
+		if err != nil {
+			return err
+		}
+		_, err = w.exec(`UPDATE State SET block = $2 WHERE chain_id = $1 AND block < $2`, last.ChainId, last.Number)
+		if err != nil {
+			return err
+		}
+		return w.notifyBlocks(last.ChainId)
+	})
+}
+
+func (w writer) insertBlockData(data customTypes.BlockData) (err error) {
+	for _, collection := range data.Collections {
+		err = w.insertCollection(collection)
+		if err != nil {
+			return err
+		}
+	}
+	// Mints come first so that the transfers of the same block find the token
+	for _, mint := range data.Mints {
+		err = w.insertMint(mint)
+		if err != nil {
+			return err
+		}
+	}
+	// The stats only count the rows written for the first time so that replaying a block is safe
+	stats := newBlockStats(data.Block.ChainId)
+	for _, transfer := range data.Txs {
+		inserted, err := w.insertTx(transfer)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			stats.addTransfer(transfer)
+		}
+		if transfer.Tag == "transfer" {
+			err = w.updateOwner(transfer)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = w.insertERC1155Data(data)
+	if err != nil {
+		return err
+	}
+	for _, sale := range data.Sales {
+		inserted, err := w.insertSale(sale)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			stats.addSale(sale)
+		}
+	}
+	err = stats.write(w)
+	if err != nil {
+		return err
+	}
+
+	return w.insertBlock(data.Block)
+}
+
 // Insert a collection
-func InserCollection(db *sql.DB, toInsert customTypes.ERC721CollectionStruct) (err error) {
+func (w writer) insertCollection(toInsert customTypes.ERC721CollectionStruct) (err error) {
 	// Insert a collection
-	insertCollection := `INSERT INTO ERC721Collection(deploy_timestamp, block_number, deploy_hash, contract_address, contract_name, contract_symbol) VALUES ($1, $2, $3, $4, $5, $6)`
-	err = exec(db, insertCollection, toInsert.DeployTimestamp, toInsert.DeployBlockNumber, toInsert.DeployTxHash, strings.ToLower(toInsert.ContractAddress.Hex()), toInsert.ContractName, toInsert.ContractSymbol)
-	if err != nil && !config.IGNORE_ERR {
+	insertCollection := `INSERT INTO ERC721Collection(chain_id, deploy_timestamp, block_number, deploy_hash, contract_address, contract_name, contract_symbol) VALUES ($1, ` + w.time("$2") + `, $3, NULLIF($4, ''), $5, $6, $7) ON CONFLICT (chain_id, contract_address) DO NOTHING`
+	_, err = w.exec(insertCollection, toInsert.ChainId, toInsert.DeployTimestamp, toInsert.DeployBlockNumber, toInsert.DeployTxHash, strings.ToLower(toInsert.ContractAddress.Hex()), toInsert.ContractName, toInsert.ContractSymbol)
+	if err != nil && !IgnoreErr {
 		log.Println("Error :", err)
 	}
 	return err
 }
 
-// Get the last block of the db
-func SelectBlock(db *sql.DB) (block uint64, err error) {
+// Get the sync checkpoint, blocks are indexed again from this one on restart
+func (s *sqlStore) SelectBlock(ctx context.Context, chainId uint64, startBlock uint64) (block uint64, err error) {
 	// Query the state
-	rows, err := db.Query("SELECT block FROM State")
+	rows, err := s.query(ctx, "SELECT block FROM State WHERE chain_id = $1", chainId)
 	if err != nil {
 		return 0, err
 	}
@@ -40,167 +135,189 @@ func SelectBlock(db *sql.DB) (block uint64, err error) {
 	}
 
 	// Insert a block
-	insertState := `INSERT INTO State(block) VALUES ($1)`
-	_, err = db.Exec(insertState, config.START_BLOCK)
+	insertState := `INSERT INTO State(chain_id, block) VALUES ($1, $2)`
+	_, err = s.exec(ctx, insertState, chainId, startBlock)
 	if err != nil {
-		return config.START_BLOCK, err
+		return startBlock, err
 	}
-	return config.START_BLOCK, nil
+	return startBlock, nil
 }
 
-// Update the last block
-func UpdateBlock(db *sql.DB, block uint64) (err error) {
-	update := `UPDATE State SET block = $1`
-	err = exec(db, update, block)
+// Move the checkpoint to the end of the run of indexed blocks starting at it.
+// Blocks may be committed out of order, the checkpoint never skips a missing one.
+func (w writer) advanceCheckpoint(chainId uint64) (err error) {
+	advance := `UPDATE State SET block = (
+		SELECT MIN(b.number) FROM Block b
+		WHERE b.chain_id = $1 AND b.number >= State.block
+		AND NOT EXISTS (SELECT 1 FROM Block n WHERE n.chain_id = $1 AND n.number = b.number + 1)
+	)
+	WHERE chain_id = $1 AND EXISTS (SELECT 1 FROM Block b WHERE b.chain_id = $1 AND b.number = State.block)`
+	_, err = w.exec(advance, chainId)
 	return err
 }
 
 // Insert a tx
-func InsertTx(db *sql.DB, toInsert customTypes.ERC721TxStruct) (err error) {
+func (w writer) insertTx(toInsert customTypes.ERC721TxStruct) (inserted bool, err error) {
 	// Insert a tx
-	insertTx := `INSERT INTO ERC721Tx(timestamp, block_number, hash, tag, from_addr, to_addr, value, token_id, collection) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	err = exec(db, insertTx, toInsert.Timestamp, toInsert.BlockNumber, toInsert.TxHash, toInsert.Tag, strings.ToLower(toInsert.FromAddr.Hex()), strings.ToLower(toInsert.ToAddr.Hex()), toInsert.Value, toInsert.TokenId, strings.ToLower(toInsert.Collection.Hex()))
-	if err != nil && !config.IGNORE_ERR {
-		log.Println("Error :", err)
+	insertTx := `INSERT INTO ERC721Tx(chain_id, timestamp, block_number, hash, log_index, tag, from_addr, to_addr, token_id, collection, price, currency, price_source, confidence)
+	VALUES ($1, ` + w.time("$2") + `, $3, $4, $5, $6, $7, $8, $9, $10, ` + w.numeric("NULLIF($11, '')") + `, NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, '')) ON CONFLICT (chain_id, hash, log_index) DO NOTHING`
+	// Transfers without price have no currency either
+	currency := ""
+	if toInsert.Price != "" {
+		currency = strings.ToLower(toInsert.Currency.Hex())
 	}
-	return err
+	result, err := w.exec(insertTx, toInsert.ChainId, toInsert.Timestamp, toInsert.BlockNumber, toInsert.TxHash, toInsert.LogIndex, toInsert.Tag, strings.ToLower(toInsert.FromAddr.Hex()), strings.ToLower(toInsert.ToAddr.Hex()), toInsert.TokenId, strings.ToLower(toInsert.Collection.Hex()), toInsert.Price, currency, toInsert.PriceSource, toInsert.Confidence)
+	if err != nil {
+		if !IgnoreErr {
+			log.Println("Error :", err)
+		}
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
 }
 
 // Update Owner
-func UpdateOwner(db *sql.DB, toUpdate customTypes.ERC721TxStruct) (err error) {
+func (w writer) updateOwner(toUpdate customTypes.ERC721TxStruct) (err error) {
 	// Update owner
-	updateOwner := `UPDATE ERC721 SET owner = $1 WHERE token_id = $2 AND collection = $3`
-	err = exec(db, updateOwner, strings.ToLower(toUpdate.ToAddr.Hex()), toUpdate.TokenId, strings.ToLower(toUpdate.Collection.Hex()))
-	if err != nil && !config.IGNORE_ERR {
+	updateOwner := `UPDATE ERC721 SET owner = $1 WHERE chain_id = $2 AND token_id = $3 AND collection = $4`
+	_, err = w.exec(updateOwner, strings.ToLower(toUpdate.ToAddr.Hex()), toUpdate.ChainId, toUpdate.TokenId, strings.ToLower(toUpdate.Collection.Hex()))
+	if err != nil && !IgnoreErr {
 		log.Println("Error :", err)
 	}
 	return err
 }
 
 // Insert a mint
-func InsertMint(db *sql.DB, toInsert customTypes.ERC721Struct) (err error) {
-	// Insert a mint
-	insertMint := `INSERT INTO ERC721(mint_timestamp, mint_block_number, mint_hash, uri, token_id, collection, owner) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	err = exec(db, insertMint, toInsert.MintTimestamp, toInsert.MintBlockNumber, toInsert.MintTxHash, toInsert.URI, toInsert.TokenId, strings.ToLower(toInsert.Collection.Hex()), strings.ToLower(toInsert.Owner.Hex()))
-	if err != nil && !config.IGNORE_ERR {
+func (w writer) insertMint(toInsert customTypes.ERC721Struct) (err error) {
+	// Insert a mint, a token minted again after a burn takes the new mint data
+	insertMint := `INSERT INTO ERC721(mint_timestamp, mint_block_number, mint_hash, uri, token_id, collection, owner, chain_id) VALUES (` + w.time("$1") + `, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (chain_id, collection, token_id) DO UPDATE SET mint_timestamp = ` + w.time("$1") + `, mint_block_number = $2, mint_hash = $3, uri = $4, owner = $7`
+	_, err = w.exec(insertMint, toInsert.MintTimestamp, toInsert.MintBlockNumber, toInsert.MintTxHash, toInsert.URI, toInsert.TokenId, strings.ToLower(toInsert.Collection.Hex()), strings.ToLower(toInsert.Owner.Hex()), toInsert.ChainId)
+	if err != nil && !IgnoreErr {
 		log.Println("Error :", err)
 	}
 	return err
 }
 
-// ///////////////////////////////////// UTILS ///////////////////////////////////////
-// Start db
-func StartDatabase() (database *sql.DB, e error) {
-	db, err := sql.Open("postgres", config.POSTGRE_URI)
-	if err != nil {
-		return nil, err
-	}
-
-	// Drop indexes
-	_, err = db.Exec("DROP INDEX IF EXISTS ERC721_collection_idx")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP INDEX IF EXISTS ERC721_owner_idx")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP INDEX IF EXISTS ERC721Tx_collection_idx")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP INDEX IF EXISTS ERC721Tx_from_idx")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP INDEX IF EXISTS ERC721Tx_to_idx")
+// Insert a sale
+func (w writer) insertSale(toInsert customTypes.SaleStruct) (inserted bool, err error) {
+	insertSale := `INSERT INTO Sale(chain_id, timestamp, block_number, hash, log_index, item_index, marketplace, collection, token_id, amount, seller, buyer, currency, price) VALUES ($1, ` + w.time("$2") + `, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) ON CONFLICT (chain_id, hash, log_index, item_index) DO NOTHING`
+	result, err := w.exec(insertSale, toInsert.ChainId, toInsert.Timestamp, toInsert.BlockNumber, toInsert.TxHash, toInsert.LogIndex, toInsert.ItemIndex, toInsert.Marketplace, strings.ToLower(toInsert.Collection.Hex()), toInsert.TokenId, toInsert.Amount, strings.ToLower(toInsert.Seller.Hex()), strings.ToLower(toInsert.Buyer.Hex()), strings.ToLower(toInsert.Currency.Hex()), toInsert.Price)
 	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP INDEX IF EXISTS ERC721Tx_token_id_and_collection_idx")
-	if err != nil {
-		return nil, err
-	}
-	// Drop tables
-	_, err = db.Exec("DROP TABLE IF EXISTS ERC721Tx")
-	if err != nil {
-		return nil, err
+		if !IgnoreErr {
+			log.Println("Error :", err)
+		}
+		return false, err
 	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
 
-	_, err = db.Exec("DROP TABLE IF EXISTS ERC721")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP TABLE IF EXISTS ERC721Collection")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DROP TABLE IF EXISTS State")
-	if err != nil {
-		return nil, err
+// Insert a processed block
+func (w writer) insertBlock(toInsert customTypes.BlockStruct) (err error) {
+	insertBlock := `INSERT INTO Block(chain_id, number, hash, parent_hash) VALUES ($1, $2, $3, $4) ON CONFLICT (chain_id, number) DO UPDATE SET hash = $3, parent_hash = $4`
+	_, err = w.exec(insertBlock, toInsert.ChainId, toInsert.Number, toInsert.Hash, toInsert.ParentHash)
+	if err != nil && !IgnoreErr {
+		log.Println("Error :", err)
 	}
+	return err
+}
 
-	for _, create := range []string{dto.ERC721_COLLECTION_TABLE, dto.ERC721_TABLE, dto.ERC721_TX_TABLE, dto.STATE_TABLE} {
-		_, err = db.Exec(create)
-		if err != nil {
-			return nil, err
-		}
-	}
+// Check if a collection is already indexed
+func (s *sqlStore) SelectCollectionExists(ctx context.Context, chainId uint64, address common.Address) (exists bool, err error) {
+	err = s.queryRow(ctx, "SELECT EXISTS (SELECT 1 FROM ERC721Collection WHERE chain_id = $1 AND contract_address = $2)", chainId, strings.ToLower(address.Hex())).Scan(&exists)
+	return exists, err
+}
 
-	// Clean the database
-	_, err = db.Exec("DELETE FROM ERC721Tx")
-	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DELETE FROM ERC721Collection")
-	if err != nil {
-		return nil, err
+// Get the highest stored block at or below number, blocks without activity may be missing in logs mode
+func (s *sqlStore) SelectBlockAtOrBelow(ctx context.Context, chainId uint64, number uint64) (stored uint64, hash string, found bool, err error) {
+	err = s.queryRow(ctx, "SELECT number, hash FROM Block WHERE chain_id = $1 AND number <= $2 ORDER BY number DESC LIMIT 1", chainId, number).Scan(&stored, &hash)
+	if err == sql.ErrNoRows {
+		return 0, "", false, nil
 	}
-	_, err = db.Exec("DELETE FROM State")
 	if err != nil {
-		return nil, err
-	}
-	_, err = db.Exec("DELETE FROM ERC721")
-	if err != nil {
-		return nil, err
+		return 0, "", false, err
 	}
+	return stored, hash, true, nil
+}
 
-	// If state already exists, return
-	block, err := SelectBlock(db)
-	if err == nil {
-		log.Println("State already exists, block :", block)
-		return db, nil
+// Get the stored hash of a block, found is false if the block was never processed
+func (s *sqlStore) SelectBlockHash(ctx context.Context, chainId uint64, number uint64) (hash string, found bool, err error) {
+	err = s.queryRow(ctx, "SELECT hash FROM Block WHERE chain_id = $1 AND number = $2", chainId, number).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", false, nil
 	}
-
-	insertState := `INSERT INTO State (block) VALUES ($1)`
-	_, err = db.Exec(insertState, config.START_BLOCK)
 	if err != nil {
-		return nil, err
+		return "", false, err
 	}
-
-	return db, nil
+	return hash, true, nil
 }
 
-func exec(db *sql.DB, query string, args ...any) (err error) {
-	tx, err := db.Begin()
+// Get the highest processed block, found is false if no block was processed yet
+func (s *sqlStore) SelectLastBlock(ctx context.Context, chainId uint64) (number uint64, found bool, err error) {
+	var last sql.NullInt64
+	err = s.queryRow(ctx, "SELECT MAX(number) FROM Block WHERE chain_id = $1", chainId).Scan(&last)
 	if err != nil {
-		return err
+		return 0, false, err
 	}
-
-	stmt, err := tx.Prepare(query)
-	if err != nil {
-		return err
+	if !last.Valid {
+		return 0, false, nil
 	}
-	defer stmt.Close()
+	return uint64(last.Int64), true, nil
+}
 
-	_, err = stmt.Exec(args...)
-	if err != nil {
-		return err
-	}
+// Remove everything written for the blocks above ancestor
+func (s *sqlStore) Rollback(ctx context.Context, chainId uint64, ancestor uint64) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		statsFrom, err := w.rollbackStatsFrom(chainId, ancestor)
+		if err != nil {
+			return err
+		}
 
-	err = tx.Commit()
-	if err != nil {
-		return err
-	}
-	return nil
+		var queries []string
+		queries = append(queries, w.erc1155Rollback()...)
+		queries = append(queries, metadataRollback...)
+		queries = append(queries, []string{
+			// Forget the NFTs minted in the orphaned blocks
+			`DELETE FROM ERC721 WHERE chain_id = $1 AND mint_block_number > $2`,
+			// Give back the tokens to their owner after the last canonical transfer
+			`UPDATE ERC721 SET owner = (
+				SELECT t.to_addr FROM ERC721Tx t
+				WHERE t.chain_id = $1 AND t.collection = ERC721.collection AND t.token_id = ERC721.token_id AND t.tag <> 'burn' AND t.block_number <= $2
+				ORDER BY t.block_number DESC, t.log_index DESC LIMIT 1
+			)
+			WHERE chain_id = $1 AND EXISTS (
+				SELECT 1 FROM ERC721Tx t
+				WHERE t.chain_id = $1 AND t.collection = ERC721.collection AND t.token_id = ERC721.token_id AND t.block_number > $2
+			)`,
+			`DELETE FROM ERC721Tx WHERE chain_id = $1 AND block_number > $2`,
+			`DELETE FROM Sale WHERE chain_id = $1 AND block_number > $2`,
+			`DELETE FROM ERC721Collection WHERE chain_id = $1 AND block_number > $2`,
+			`DELETE FROM Block WHERE chain_id = $1 AND number > $2`,
+			`UPDATE State SET block = $2 WHERE chain_id = $1 AND block > $2`,
+			// Tell the streams that passed the ancestor to go back to it
+			`INSERT INTO Reorg(chain_id, ancestor) VALUES ($1, $2)`,
+		}...)
+		for _, query := range queries {
+			_, err = w.exec(query, chainId, ancestor)
+			if err != nil {
+				return err
+			}
+		}
+		// The rows emptied by the queries above only depend on the chain
+		for _, query := range []string{erc1155EmptyBalances, emptyTraitCounts} {
+			_, err = w.exec(query, chainId)
+			if err != nil {
+				return err
+			}
+		}
+		if statsFrom.Valid {
+			err = w.rebuildStats(chainId, uint64(statsFrom.Int64))
+			if err != nil {
+				return err
+			}
+		}
+		return w.notifyBlocks(chainId)
+	})
 }
@@ -0,0 +1,53 @@
+package dbtest
+
+import (
+	"fmt"
+
+	"workspace/customTypes"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const Chain = 1
+
+var (
+	Collection = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	ERC1155    = common.HexToAddress("0x00000000000000000000000000000000000000c2")
+	Alice      = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	Bob        = common.HexToAddress("0x00000000000000000000000000000000000000b0")
+)
+
+// Block number of the test chain, linked to the previous number
+func Block(number uint64) customTypes.BlockStruct {
+	return customTypes.BlockStruct{ChainId: Chain, Number: number, Hash: fmt.Sprintf("0x%064x", number), ParentHash: fmt.Sprintf("0x%064x", number-1), Timestamp: 1700000000 + number*12}
+}
+
+// Deployment of the test collection in block number
+func Deployment(number uint64) customTypes.ERC721CollectionStruct {
+	b := Block(number)
+	return customTypes.ERC721CollectionStruct{ChainId: Chain, ContractAddress: Collection, ContractName: "Test", ContractSymbol: "TST", DeployTimestamp: b.Timestamp, DeployBlockNumber: number, DeployTxHash: b.Hash}
+}
+
+// Block minting tokenIds of the test collection to Alice, one transfer each
+func MintBlock(number uint64, tokenIds ...string) customTypes.BlockData {
+	data := customTypes.BlockData{Block: Block(number)}
+	for i, tokenId := range tokenIds {
+		data.Mints = append(data.Mints, customTypes.ERC721Struct{ChainId: Chain, MintTimestamp: data.Block.Timestamp, MintBlockNumber: number, MintTxHash: data.Block.Hash, URI: "ipfs://Qm/" + tokenId, TokenId: tokenId, Collection: Collection, Owner: Alice})
+		data.Txs = append(data.Txs, Transfer(number, uint(i), "mint", common.Address{}, Alice, tokenId))
+	}
+	return data
+}
+
+// Transfer of tokenId of the test collection in block number
+func Transfer(number uint64, logIndex uint, tag string, from common.Address, to common.Address, tokenId string) customTypes.ERC721TxStruct {
+	b := Block(number)
+	return customTypes.ERC721TxStruct{ChainId: Chain, Timestamp: b.Timestamp, BlockNumber: number, TxHash: b.Hash, LogIndex: logIndex, Tag: tag, FromAddr: from, ToAddr: to, TokenId: tokenId, Collection: Collection}
+}
+
+// Block minting amount of tokenId of the ERC-1155 collection to Alice
+func ERC1155MintBlock(number uint64, tokenId string, amount string) customTypes.BlockData {
+	data := customTypes.BlockData{Block: Block(number)}
+	data.ERC1155Tokens = []customTypes.ERC1155TokenStruct{{ChainId: Chain, MintTimestamp: data.Block.Timestamp, MintBlockNumber: number, MintTxHash: data.Block.Hash, URI: "ipfs://Qm/{id}", TokenId: tokenId, Collection: ERC1155}}
+	data.ERC1155Txs = []customTypes.ERC1155TxStruct{{ChainId: Chain, Timestamp: data.Block.Timestamp, BlockNumber: number, TxHash: data.Block.Hash, Tag: "mint", Operator: Alice, ToAddr: Alice, TokenId: tokenId, Amount: amount, Collection: ERC1155}}
+	return data
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"regexp"
+)
+
+// SQL that differs between the backends. Queries are written with the $n placeholders of Postgres
+// and build the expressions below through the dialect of their store.
+type dialect interface {
+	// Name of the backend in the configuration, also the directory of its migrations
+	name() string
+	// Registered database/sql driver
+	driver() string
+	// Table recording the applied migrations
+	schemaMigrations() string
+	// Query with its placeholders rewritten for the driver
+	rebind(query string) string
+	// Statement notifying a channel with the $1 payload, empty when the backend has no notifications
+	notify(channel string) string
+	// End of a SELECT locking the rows of alias it returns, the rows locked by another transaction are left out
+	skipLocked(alias string) string
+
+	// Stored time from a unix timestamp, and unix timestamp of a stored time
+	time(expr string) string
+	epoch(expr string) string
+
+	// Arithmetic on the uint256 columns
+	numeric(expr string) string
+	add(a string, b string) string
+	neg(expr string) string
+	sum(expr string) string
+	min(expr string) string
+	least(a string, b string) string
+	div(a string, b string) string
+	// Keys ordering a uint256 column by value, each followed by order
+	uintKey(expr string, order string) string
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) name() string   { return "postgres" }
+func (postgresDialect) driver() string { return "postgres" }
+
+func (postgresDialect) schemaMigrations() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint NOT NULL PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+}
+
+func (postgresDialect) rebind(query string) string { return query }
+
+func (postgresDialect) notify(channel string) string {
+	return "SELECT pg_notify('" + channel + "', $1)"
+}
+
+func (postgresDialect) skipLocked(alias string) string {
+	return " FOR UPDATE OF " + alias + " SKIP LOCKED"
+}
+
+func (postgresDialect) time(expr string) string { return "to_timestamp(" + expr + ")" }
+func (postgresDialect) epoch(expr string) string {
+	return "CAST(extract(epoch from " + expr + ") AS bigint)"
+}
+
+func (postgresDialect) numeric(expr string) string      { return "CAST(" + expr + " AS numeric)" }
+func (postgresDialect) add(a string, b string) string   { return "(" + a + " + " + b + ")" }
+func (postgresDialect) neg(expr string) string          { return "-" + expr }
+func (postgresDialect) sum(expr string) string          { return "SUM(" + expr + ")" }
+func (postgresDialect) min(expr string) string          { return "MIN(" + expr + ")" }
+func (postgresDialect) least(a string, b string) string { return "LEAST(" + a + ", " + b + ")" }
+func (postgresDialect) div(a string, b string) string   { return "div(" + a + ", " + b + ")" }
+
+func (postgresDialect) uintKey(expr string, order string) string { return expr + order }
+
+// Times are stored as unix timestamps and the uint256 values as decimal text, handled by the functions of sqliteFunctions
+type sqliteDialect struct{}
+
+func (sqliteDialect) name() string   { return "sqlite" }
+func (sqliteDialect) driver() string { return sqliteDriver }
+
+func (sqliteDialect) schemaMigrations() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version integer NOT NULL PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+}
+
+var placeholder = regexp.MustCompile(`\$([0-9]+)`)
+
+// ?n binds the nth argument wherever it appears, like $n
+func (sqliteDialect) rebind(query string) string { return placeholder.ReplaceAllString(query, "?$1") }
+
+func (sqliteDialect) notify(channel string) string { return "" }
+
+// Transactions take the write lock when they begin (_txlock=immediate), the selected rows cannot be claimed by another one
+func (sqliteDialect) skipLocked(alias string) string { return "" }
+
+func (sqliteDialect) time(expr string) string  { return expr }
+func (sqliteDialect) epoch(expr string) string { return expr }
+
+func (sqliteDialect) numeric(expr string) string      { return expr }
+func (sqliteDialect) add(a string, b string) string   { return "numeric_add(" + a + ", " + b + ")" }
+func (sqliteDialect) neg(expr string) string          { return "numeric_neg(" + expr + ")" }
+func (sqliteDialect) sum(expr string) string          { return "numeric_sum(" + expr + ")" }
+func (sqliteDialect) min(expr string) string          { return "numeric_min(" + expr + ")" }
+func (sqliteDialect) least(a string, b string) string { return "numeric_least(" + a + ", " + b + ")" }
+func (sqliteDialect) div(a string, b string) string   { return "numeric_div(" + a + ", " + b + ")" }
+
+// Decimal text without leading zeros is in numeric order once sorted by length
+func (sqliteDialect) uintKey(expr string, order string) string {
+	return "length(" + expr + ")" + order + ", " + expr + order
+}
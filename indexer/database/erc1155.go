@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"workspace/customTypes"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func (w writer) insertERC1155Data(data customTypes.BlockData) (err error) {
+	for _, collection := range data.ERC1155Collections {
+		err = w.insertERC1155Collection(collection)
+		if err != nil {
+			return err
+		}
+	}
+	for _, token := range data.ERC1155Tokens {
+		err = w.insertERC1155Token(token)
+		if err != nil {
+			return err
+		}
+	}
+	for _, transfer := range data.ERC1155Txs {
+		inserted, err := w.insertERC1155Tx(transfer)
+		if err != nil {
+			return err
+		}
+		// The balances only move the first time a transfer is written so that replaying a block is safe
+		if !inserted {
+			continue
+		}
+		if transfer.Tag != "mint" {
+			err = w.updateBalance(transfer.ChainId, transfer.Collection, transfer.TokenId, transfer.FromAddr, "-"+transfer.Amount)
+			if err != nil {
+				return err
+			}
+		}
+		if transfer.Tag != "burn" {
+			err = w.updateBalance(transfer.ChainId, transfer.Collection, transfer.TokenId, transfer.ToAddr, transfer.Amount)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, uri := range data.ERC1155Uris {
+		err = w.insertERC1155Uri(uri)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Insert an ERC-1155 collection
+func (w writer) insertERC1155Collection(toInsert customTypes.ERC1155CollectionStruct) (err error) {
+	insertCollection := `INSERT INTO ERC1155Collection(chain_id, deploy_timestamp, block_number, deploy_hash, contract_address, contract_name, contract_symbol) VALUES ($1, ` + w.time("$2") + `, $3, NULLIF($4, ''), $5, $6, $7) ON CONFLICT (chain_id, contract_address) DO NOTHING`
+	_, err = w.exec(insertCollection, toInsert.ChainId, toInsert.DeployTimestamp, toInsert.DeployBlockNumber, toInsert.DeployTxHash, strings.ToLower(toInsert.ContractAddress.Hex()), toInsert.ContractName, toInsert.ContractSymbol)
+	if err != nil && !IgnoreErr {
+		log.Println("Error :", err)
+	}
+	return err
+}
+
+// Insert an ERC-1155 token at its first mint, minting more of it keeps the first mint data
+func (w writer) insertERC1155Token(toInsert customTypes.ERC1155TokenStruct) (err error) {
+	insertToken := `INSERT INTO ERC1155Token(chain_id, mint_timestamp, mint_block_number, mint_hash, uri, token_id, collection) VALUES ($1, ` + w.time("$2") + `, $3, $4, $5, $6, $7) ON CONFLICT (chain_id, collection, token_id) DO NOTHING`
+	_, err = w.exec(insertToken, toInsert.ChainId, toInsert.MintTimestamp, toInsert.MintBlockNumber, toInsert.MintTxHash, toInsert.URI, toInsert.TokenId, strings.ToLower(toInsert.Collection.Hex()))
+	if err != nil && !IgnoreErr {
+		log.Println("Error :", err)
+	}
+	return err
+}
+
+// Insert an ERC-1155 transfer, inserted is false when it was already written
+func (w writer) insertERC1155Tx(toInsert customTypes.ERC1155TxStruct) (inserted bool, err error) {
+	insertTx := `INSERT INTO ERC1155Tx(chain_id, timestamp, block_number, hash, log_index, batch_index, tag, operator, from_addr, to_addr, token_id, amount, collection, price, currency, price_source, confidence)
+	VALUES ($1, ` + w.time("$2") + `, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, ` + w.numeric("NULLIF($14, '')") + `, NULLIF($15, ''), NULLIF($16, ''), NULLIF($17, '')) ON CONFLICT (chain_id, hash, log_index, batch_index) DO NOTHING`
+	// Transfers without price have no currency either
+	currency := ""
+	if toInsert.Price != "" {
+		currency = strings.ToLower(toInsert.Currency.Hex())
+	}
+	result, err := w.exec(insertTx, toInsert.ChainId, toInsert.Timestamp, toInsert.BlockNumber, toInsert.TxHash, toInsert.LogIndex, toInsert.BatchIndex, toInsert.Tag, strings.ToLower(toInsert.Operator.Hex()), strings.ToLower(toInsert.FromAddr.Hex()), strings.ToLower(toInsert.ToAddr.Hex()), toInsert.TokenId, toInsert.Amount, strings.ToLower(toInsert.Collection.Hex()), toInsert.Price, currency, toInsert.PriceSource, toInsert.Confidence)
+	if err != nil {
+		if !IgnoreErr {
+			log.Println("Error :", err)
+		}
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// Add delta to the balance of a holder, a balance back to zero is removed
+func (w writer) updateBalance(chainId uint64, collection common.Address, tokenId string, holder common.Address, delta string) (err error) {
+	updateBalance := `INSERT INTO ERC1155Balance(chain_id, collection, token_id, holder, balance) VALUES ($1, $2, $3, $4, ` + w.numeric("$5") + `)
+	ON CONFLICT (chain_id, collection, token_id, holder) DO UPDATE SET balance = ` + w.add("ERC1155Balance.balance", "EXCLUDED.balance")
+	_, err = w.exec(updateBalance, chainId, strings.ToLower(collection.Hex()), tokenId, strings.ToLower(holder.Hex()), delta)
+	if err == nil {
+		_, err = w.exec(`DELETE FROM ERC1155Balance WHERE chain_id = $1 AND collection = $2 AND token_id = $3 AND holder = $4 AND balance = 0`, chainId, strings.ToLower(collection.Hex()), tokenId, strings.ToLower(holder.Hex()))
+	}
+	if err != nil && !IgnoreErr {
+		log.Println("Error :", err)
+	}
+	return err
+}
+
+// Insert an URI event
+func (w writer) insertERC1155Uri(toInsert customTypes.ERC1155UriStruct) (err error) {
+	insertUri := `INSERT INTO ERC1155Uri(chain_id, block_number, hash, log_index, token_id, collection, uri) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (chain_id, hash, log_index) DO NOTHING`
+	_, err = w.exec(insertUri, toInsert.ChainId, toInsert.BlockNumber, toInsert.TxHash, toInsert.LogIndex, toInsert.TokenId, strings.ToLower(toInsert.Collection.Hex()), toInsert.URI)
+	if err != nil && !IgnoreErr {
+		log.Println("Error :", err)
+	}
+	return err
+}
+
+// Queries removing the ERC-1155 rows of the blocks above $2, balances are reverted before their transfers are deleted.
+// The balances they empty are removed by erc1155EmptyBalances
+func (w writer) erc1155Rollback() []string {
+	return []string{
+		`DELETE FROM ERC1155Token WHERE chain_id = $1 AND mint_block_number > $2`,
+		// Give back what was sent and take back what was received in the orphaned blocks
+		`INSERT INTO ERC1155Balance(chain_id, collection, token_id, holder, balance)
+		SELECT chain_id, collection, token_id, holder, ` + w.sum("delta") + ` FROM (
+			SELECT chain_id, collection, token_id, from_addr AS holder, amount AS delta FROM ERC1155Tx
+			WHERE chain_id = $1 AND block_number > $2 AND tag <> 'mint'
+			UNION ALL
+			SELECT chain_id, collection, token_id, to_addr AS holder, ` + w.neg("amount") + ` AS delta FROM ERC1155Tx
+			WHERE chain_id = $1 AND block_number > $2 AND tag <> 'burn'
+		) moves
+		GROUP BY chain_id, collection, token_id, holder
+		ON CONFLICT (chain_id, collection, token_id, holder) DO UPDATE SET balance = ` + w.add("ERC1155Balance.balance", "EXCLUDED.balance"),
+		`DELETE FROM ERC1155Tx WHERE chain_id = $1 AND block_number > $2`,
+		`DELETE FROM ERC1155Uri WHERE chain_id = $1 AND block_number > $2`,
+		`DELETE FROM ERC1155Collection WHERE chain_id = $1 AND block_number > $2`,
+	}
+}
+
+// Removes the balances emptied by a rollback, it only takes the chain as $1
+const erc1155EmptyBalances = `DELETE FROM ERC1155Balance WHERE chain_id = $1 AND balance = 0`
+
+// Check if an ERC-1155 collection is already indexed
+func (s *sqlStore) SelectERC1155CollectionExists(ctx context.Context, chainId uint64, address common.Address) (exists bool, err error) {
+	err = s.queryRow(ctx, "SELECT EXISTS (SELECT 1 FROM ERC1155Collection WHERE chain_id = $1 AND contract_address = $2)", chainId, strings.ToLower(address.Hex())).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"workspace/customTypes"
+)
+
+// Get the tokens whose metadata was never fetched, is due for a retry or whose uri changed.
+// The attempts start again from zero for a new uri.
+func (s *sqlStore) SelectPendingMetadata(ctx context.Context, limit int) (tasks []customTypes.MetadataTask, err error) {
+	rows, err := s.query(ctx, `SELECT n.chain_id, n.collection, n.token_id, n.uri, COALESCE(CASE WHEN m.uri = n.uri THEN m.attempts END, 0) FROM ERC721 n
+	LEFT JOIN ERC721Metadata m ON m.chain_id = n.chain_id AND m.collection = n.collection AND m.token_id = n.token_id
+	WHERE n.uri <> '' AND (m.chain_id IS NULL OR m.uri <> n.uri OR (m.status = 'pending' AND m.next_attempt <= `+s.time("$2")+`))
+	LIMIT $1`, limit, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task customTypes.MetadataTask
+		err = rows.Scan(&task.ChainId, &task.Collection, &task.TokenId, &task.URI, &task.Attempts)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// Store a fetched metadata document with its traits
+func (s *sqlStore) InsertMetadata(ctx context.Context, task customTypes.MetadataTask, metadata customTypes.MetadataStruct) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		// A document without attributes stores NULL rather than an invalid empty JSON value
+		var attributes interface{}
+		if len(metadata.Attributes) > 0 {
+			attributes = string(metadata.Attributes)
+		}
+		now := w.time("$10")
+		insertMetadata := `INSERT INTO ERC721Metadata(chain_id, collection, token_id, uri, status, attempts, next_attempt, last_error, name, description, image, attributes, document, fetched_at)
+		VALUES ($1, $2, $3, $4, 'done', 0, ` + now + `, NULL, $5, $6, $7, $8, $9, ` + now + `)
+		ON CONFLICT (chain_id, collection, token_id) DO UPDATE SET uri = $4, status = 'done', attempts = 0, next_attempt = ` + now + `, last_error = NULL,
+		name = $5, description = $6, image = $7, attributes = $8, document = $9, fetched_at = ` + now
+		_, err := w.exec(insertMetadata, task.ChainId, task.Collection, task.TokenId, task.URI, metadata.Name, metadata.Description, metadata.Image, attributes, string(metadata.Document), time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		return w.replaceTraits(task, metadata.Traits)
+	})
+}
+
+// Record a failed fetch, the document is retried at nextAttempt while status is pending
+func (s *sqlStore) UpdateMetadataFailure(ctx context.Context, task customTypes.MetadataTask, status string, nextAttempt time.Time, reason string) (err error) {
+	updateMetadata := `INSERT INTO ERC721Metadata(chain_id, collection, token_id, uri, status, attempts, next_attempt, last_error) VALUES ($1, $2, $3, $4, $5, $6, ` + s.time("$7") + `, $8)
+	ON CONFLICT (chain_id, collection, token_id) DO UPDATE SET uri = $4, status = $5, attempts = $6, next_attempt = ` + s.time("$7") + `, last_error = $8`
+	_, err = s.exec(ctx, updateMetadata, task.ChainId, task.Collection, task.TokenId, task.URI, status, task.Attempts+1, nextAttempt.Unix(), reason)
+	return err
+}
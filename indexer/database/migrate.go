@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"workspace/database/migrations"
+)
+
+// State of a migration in the database
+type MigrationStatus struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Apply every pending migration, returns the number of applied migrations
+func (s *sqlStore) MigrateUp(ctx context.Context) (applied int, err error) {
+	all, err := migrations.All(s.name())
+	if err != nil {
+		return 0, err
+	}
+	done, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, migration := range all {
+		if _, ok := done[migration.Version]; ok {
+			continue
+		}
+		err = s.runMigration(ctx, migration.Up, `INSERT INTO schema_migrations(version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+		if err != nil {
+			return applied, err
+		}
+		log.Println("Applied migration", migration.Version, migration.Name)
+		applied++
+	}
+	return applied, nil
+}
+
+// Revert the last steps applied migrations, returns the number of reverted migrations
+func (s *sqlStore) MigrateDown(ctx context.Context, steps int) (reverted int, err error) {
+	all, err := migrations.All(s.name())
+	if err != nil {
+		return 0, err
+	}
+	done, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(all) - 1; i >= 0 && reverted < steps; i-- {
+		migration := all[i]
+		if _, ok := done[migration.Version]; !ok {
+			continue
+		}
+		err = s.runMigration(ctx, migration.Down, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+		if err != nil {
+			return reverted, err
+		}
+		log.Println("Reverted migration", migration.Version, migration.Name)
+		reverted++
+	}
+	return reverted, nil
+}
+
+// Get the state of every known migration
+func (s *sqlStore) SelectMigrations(ctx context.Context) ([]MigrationStatus, error) {
+	all, err := migrations.All(s.name())
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(all))
+	for _, migration := range all {
+		appliedAt, applied := done[migration.Version]
+		status = append(status, MigrationStatus{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   applied,
+			AppliedAt: appliedAt,
+		})
+	}
+	return status, nil
+}
+
+// Revert every migration and apply them again, this deletes all the indexed data
+func (s *sqlStore) ResetDatabase(ctx context.Context) (err error) {
+	all, err := migrations.All(s.name())
+	if err != nil {
+		return err
+	}
+	_, err = s.MigrateDown(ctx, len(all))
+	if err != nil {
+		return err
+	}
+	_, err = s.MigrateUp(ctx)
+	return err
+}
+
+func (s *sqlStore) appliedMigrations(ctx context.Context) (map[uint64]time.Time, error) {
+	_, err := s.exec(ctx, s.schemaMigrations())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[uint64]time.Time{}
+	for rows.Next() {
+		var version uint64
+		var appliedAt time.Time
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+	return done, rows.Err()
+}
+
+// Run a migration script and record it in the same transaction
+func (s *sqlStore) runMigration(ctx context.Context, script string, record string, args ...any) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		_, err := w.tx.ExecContext(ctx, script)
+		if err != nil {
+			return err
+		}
+		_, err = w.exec(record, args...)
+		return err
+	})
+}
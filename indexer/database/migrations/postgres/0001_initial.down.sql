@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS ERC721_collection_idx;
+DROP INDEX IF EXISTS ERC721_owner_idx;
+DROP INDEX IF EXISTS ERC721Tx_collection_idx;
+DROP INDEX IF EXISTS ERC721Tx_from_idx;
+DROP INDEX IF EXISTS ERC721Tx_to_idx;
+DROP INDEX IF EXISTS ERC721Tx_token_id_and_collection_idx;
+
+DROP TABLE IF EXISTS ERC721Tx;
+DROP TABLE IF EXISTS ERC721;
+DROP TABLE IF EXISTS ERC721Collection;
+DROP TABLE IF EXISTS State;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS Block (
+	number bigint NOT NULL PRIMARY KEY,
+	hash text NOT NULL,
+	parent_hash text NOT NULL
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ERC721Tx_hash_log_index_idx;
+
+ALTER TABLE ERC721Tx DROP COLUMN IF EXISTS log_index;
@@ -0,0 +1,3 @@
+ALTER TABLE ERC721Tx ADD COLUMN IF NOT EXISTS log_index integer;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ERC721Tx_hash_log_index_idx ON ERC721Tx(hash, log_index);
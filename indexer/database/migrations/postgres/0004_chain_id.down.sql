@@ -0,0 +1,37 @@
+-- Only Linea mainnet rows fit in the single chain schema
+DELETE FROM ERC721Collection WHERE chain_id <> 59144;
+DELETE FROM ERC721 WHERE chain_id <> 59144;
+DELETE FROM ERC721Tx WHERE chain_id <> 59144;
+DELETE FROM State WHERE chain_id <> 59144;
+DELETE FROM Block WHERE chain_id <> 59144;
+
+ALTER TABLE Block DROP CONSTRAINT block_pkey;
+ALTER TABLE Block DROP COLUMN chain_id;
+ALTER TABLE Block ADD PRIMARY KEY (number);
+
+ALTER TABLE State DROP CONSTRAINT state_pkey;
+ALTER TABLE State DROP COLUMN chain_id;
+ALTER TABLE State ADD PRIMARY KEY (block);
+
+DROP INDEX IF EXISTS ERC721Tx_hash_log_index_idx;
+DROP INDEX IF EXISTS ERC721Tx_collection_idx;
+DROP INDEX IF EXISTS ERC721Tx_from_idx;
+DROP INDEX IF EXISTS ERC721Tx_to_idx;
+DROP INDEX IF EXISTS ERC721Tx_token_id_and_collection_idx;
+ALTER TABLE ERC721Tx DROP COLUMN chain_id;
+CREATE UNIQUE INDEX ERC721Tx_hash_log_index_idx ON ERC721Tx(hash, log_index);
+CREATE INDEX ERC721Tx_collection_idx ON ERC721Tx(collection);
+CREATE INDEX ERC721Tx_from_idx ON ERC721Tx(from_addr);
+CREATE INDEX ERC721Tx_to_idx ON ERC721Tx(to_addr);
+CREATE INDEX ERC721Tx_token_id_and_collection_idx ON ERC721Tx(token_id, collection);
+
+DROP INDEX IF EXISTS ERC721_owner_idx;
+ALTER TABLE ERC721 DROP CONSTRAINT erc721_pkey;
+ALTER TABLE ERC721 DROP COLUMN chain_id;
+ALTER TABLE ERC721 ADD PRIMARY KEY (token_id, collection);
+CREATE INDEX ERC721_collection_idx ON ERC721(collection);
+CREATE INDEX ERC721_owner_idx ON ERC721(owner);
+
+ALTER TABLE ERC721Collection DROP CONSTRAINT erc721collection_pkey;
+ALTER TABLE ERC721Collection DROP COLUMN chain_id;
+ALTER TABLE ERC721Collection ADD PRIMARY KEY (contract_address);
@@ -0,0 +1,36 @@
+-- Rows indexed before this migration come from Linea mainnet
+ALTER TABLE ERC721Collection ADD COLUMN chain_id bigint NOT NULL DEFAULT 59144;
+ALTER TABLE ERC721Collection ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE ERC721Collection DROP CONSTRAINT erc721collection_pkey;
+ALTER TABLE ERC721Collection ADD PRIMARY KEY (chain_id, contract_address);
+
+ALTER TABLE ERC721 ADD COLUMN chain_id bigint NOT NULL DEFAULT 59144;
+ALTER TABLE ERC721 ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE ERC721 DROP CONSTRAINT erc721_pkey;
+ALTER TABLE ERC721 ADD PRIMARY KEY (chain_id, collection, token_id);
+DROP INDEX IF EXISTS ERC721_collection_idx;
+DROP INDEX IF EXISTS ERC721_owner_idx;
+CREATE INDEX ERC721_owner_idx ON ERC721(chain_id, owner);
+
+ALTER TABLE ERC721Tx ADD COLUMN chain_id bigint NOT NULL DEFAULT 59144;
+ALTER TABLE ERC721Tx ALTER COLUMN chain_id DROP DEFAULT;
+DROP INDEX IF EXISTS ERC721Tx_hash_log_index_idx;
+DROP INDEX IF EXISTS ERC721Tx_collection_idx;
+DROP INDEX IF EXISTS ERC721Tx_from_idx;
+DROP INDEX IF EXISTS ERC721Tx_to_idx;
+DROP INDEX IF EXISTS ERC721Tx_token_id_and_collection_idx;
+CREATE UNIQUE INDEX ERC721Tx_hash_log_index_idx ON ERC721Tx(chain_id, hash, log_index);
+CREATE INDEX ERC721Tx_collection_idx ON ERC721Tx(chain_id, collection);
+CREATE INDEX ERC721Tx_from_idx ON ERC721Tx(chain_id, from_addr);
+CREATE INDEX ERC721Tx_to_idx ON ERC721Tx(chain_id, to_addr);
+CREATE INDEX ERC721Tx_token_id_and_collection_idx ON ERC721Tx(chain_id, collection, token_id);
+
+ALTER TABLE State ADD COLUMN chain_id bigint NOT NULL DEFAULT 59144;
+ALTER TABLE State ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE State DROP CONSTRAINT state_pkey;
+ALTER TABLE State ADD PRIMARY KEY (chain_id);
+
+ALTER TABLE Block ADD COLUMN chain_id bigint NOT NULL DEFAULT 59144;
+ALTER TABLE Block ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE Block DROP CONSTRAINT block_pkey;
+ALTER TABLE Block ADD PRIMARY KEY (chain_id, number);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ERC1155Uri;
+DROP TABLE IF EXISTS ERC1155Balance;
+DROP TABLE IF EXISTS ERC1155Tx;
+DROP TABLE IF EXISTS ERC1155Token;
+DROP TABLE IF EXISTS ERC1155Collection;
@@ -0,0 +1,72 @@
+CREATE TABLE IF NOT EXISTS ERC1155Collection (
+	chain_id bigint NOT NULL,
+	deploy_timestamp text NOT NULL,
+	block_number text NOT NULL,
+	deploy_hash text NOT NULL,
+	contract_address text NOT NULL,
+	contract_name text,
+	contract_symbol text,
+	PRIMARY KEY (chain_id, contract_address)
+);
+
+-- A token is recorded at its first mint, uri is read from the contract at that time
+CREATE TABLE IF NOT EXISTS ERC1155Token (
+	chain_id bigint NOT NULL,
+	mint_timestamp text,
+	mint_block_number text,
+	mint_hash text,
+	uri text,
+	token_id text NOT NULL,
+	collection text NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+-- One row per transferred id, batch_index is the position of the id in a TransferBatch
+CREATE TABLE IF NOT EXISTS ERC1155Tx (
+	id SERIAL PRIMARY KEY,
+	chain_id bigint NOT NULL,
+	timestamp text,
+	block_number text,
+	hash text,
+	log_index integer,
+	batch_index integer,
+	tag text,
+	operator text,
+	from_addr text,
+	to_addr text,
+	value text,
+	token_id text,
+	amount numeric NOT NULL,
+	collection text
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ERC1155Tx_hash_log_index_idx ON ERC1155Tx(chain_id, hash, log_index, batch_index);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_collection_idx ON ERC1155Tx(chain_id, collection);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_from_idx ON ERC1155Tx(chain_id, from_addr);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_to_idx ON ERC1155Tx(chain_id, to_addr);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_token_id_and_collection_idx ON ERC1155Tx(chain_id, collection, token_id);
+
+CREATE TABLE IF NOT EXISTS ERC1155Balance (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	holder text NOT NULL,
+	balance numeric NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id, holder)
+);
+
+CREATE INDEX IF NOT EXISTS ERC1155Balance_holder_idx ON ERC1155Balance(chain_id, holder);
+
+-- URI events, the last one of a token replaces the uri read at mint
+CREATE TABLE IF NOT EXISTS ERC1155Uri (
+	chain_id bigint NOT NULL,
+	block_number text NOT NULL,
+	hash text NOT NULL,
+	log_index integer NOT NULL,
+	token_id text NOT NULL,
+	collection text NOT NULL,
+	uri text NOT NULL,
+	PRIMARY KEY (chain_id, hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS ERC1155Uri_token_id_and_collection_idx ON ERC1155Uri(chain_id, collection, token_id);
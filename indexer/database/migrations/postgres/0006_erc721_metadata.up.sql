@@ -0,0 +1,20 @@
+-- Off-chain metadata of the ERC-721 tokens, status is pending, done or failed
+CREATE TABLE IF NOT EXISTS ERC721Metadata (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	uri text NOT NULL,
+	status text NOT NULL,
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt timestamptz NOT NULL DEFAULT now(),
+	last_error text,
+	name text,
+	description text,
+	image text,
+	attributes jsonb,
+	document jsonb,
+	fetched_at timestamptz,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721Metadata_pending_idx ON ERC721Metadata(next_attempt) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS ERC721RarityStale;
+DROP TABLE IF EXISTS ERC721Rarity;
+DROP TABLE IF EXISTS ERC721TraitCount;
+DROP TABLE IF EXISTS ERC721Attribute;
@@ -0,0 +1,41 @@
+-- Normalised attributes of the fetched metadata documents
+CREATE TABLE IF NOT EXISTS ERC721Attribute (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	trait_type text NOT NULL,
+	value text NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id, trait_type, value)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721Attribute_trait_idx ON ERC721Attribute(chain_id, collection, trait_type, value);
+
+-- Number of tokens of a collection having each trait, kept up to date with the attributes
+CREATE TABLE IF NOT EXISTS ERC721TraitCount (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	trait_type text NOT NULL,
+	value text NOT NULL,
+	count integer NOT NULL,
+	PRIMARY KEY (chain_id, collection, trait_type, value)
+);
+
+-- Rarity of the tokens, rank 1 is the rarest of its collection
+CREATE TABLE IF NOT EXISTS ERC721Rarity (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	score double precision NOT NULL,
+	trait_count integer NOT NULL,
+	rank integer NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721Rarity_rank_idx ON ERC721Rarity(chain_id, collection, rank);
+
+-- Collections whose traits changed since their rarity was computed
+CREATE TABLE IF NOT EXISTS ERC721RarityStale (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	PRIMARY KEY (chain_id, collection)
+);
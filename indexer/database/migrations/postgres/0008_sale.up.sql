@@ -0,0 +1,22 @@
+-- NFTs sold on a marketplace, price is the share of the order paid for the tokens of the row, in the smallest unit of currency.
+-- currency is the zero address for the native currency
+CREATE TABLE IF NOT EXISTS Sale (
+	chain_id bigint NOT NULL,
+	timestamp text NOT NULL,
+	block_number text NOT NULL,
+	hash text NOT NULL,
+	log_index integer NOT NULL,
+	item_index integer NOT NULL,
+	marketplace text NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	amount numeric NOT NULL,
+	seller text NOT NULL,
+	buyer text NOT NULL,
+	currency text NOT NULL,
+	price numeric NOT NULL,
+	PRIMARY KEY (chain_id, hash, log_index, item_index)
+);
+
+CREATE INDEX IF NOT EXISTS Sale_collection_idx ON Sale(chain_id, collection, timestamp);
+CREATE INDEX IF NOT EXISTS Sale_token_id_and_collection_idx ON Sale(chain_id, collection, token_id);
@@ -0,0 +1,5 @@
+ALTER TABLE ERC721Tx DROP COLUMN IF EXISTS confidence;
+ALTER TABLE ERC721Tx DROP COLUMN IF EXISTS price_source;
+ALTER TABLE ERC721Tx DROP COLUMN IF EXISTS currency;
+ALTER TABLE ERC721Tx DROP COLUMN IF EXISTS price;
+ALTER TABLE ERC721Tx ADD COLUMN IF NOT EXISTS value text;
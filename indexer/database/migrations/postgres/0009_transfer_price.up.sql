@@ -0,0 +1,7 @@
+-- The raw value of the transaction is replaced by the price of the token inferred from its payments.
+-- price is NULL when no payment was found, price_source is marketplace, native or erc20 and confidence high, medium or low
+ALTER TABLE ERC721Tx DROP COLUMN IF EXISTS value;
+ALTER TABLE ERC721Tx ADD COLUMN IF NOT EXISTS price numeric;
+ALTER TABLE ERC721Tx ADD COLUMN IF NOT EXISTS currency text;
+ALTER TABLE ERC721Tx ADD COLUMN IF NOT EXISTS price_source text;
+ALTER TABLE ERC721Tx ADD COLUMN IF NOT EXISTS confidence text;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS CollectionStatsParticipant;
+DROP TABLE IF EXISTS CollectionStats;
@@ -0,0 +1,31 @@
+-- Activity of a collection over an hour or a day, bucket is the unix timestamp of its start.
+-- volume, floor and native_tokens only count the sales paid in the native currency, holders is NULL for the past buckets
+-- of a rebuild since the owners at that time are not known
+CREATE TABLE IF NOT EXISTS CollectionStats (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	period text NOT NULL,
+	bucket bigint NOT NULL,
+	transfers integer NOT NULL DEFAULT 0,
+	mints integer NOT NULL DEFAULT 0,
+	burns integer NOT NULL DEFAULT 0,
+	sales integer NOT NULL DEFAULT 0,
+	native_tokens numeric NOT NULL DEFAULT 0,
+	volume numeric NOT NULL DEFAULT 0,
+	floor numeric,
+	buyers integer NOT NULL DEFAULT 0,
+	sellers integer NOT NULL DEFAULT 0,
+	holders integer,
+	PRIMARY KEY (chain_id, collection, period, bucket)
+);
+
+-- Addresses that bought or sold in a bucket, they give the unique buyers and sellers of any window
+CREATE TABLE IF NOT EXISTS CollectionStatsParticipant (
+	chain_id bigint NOT NULL,
+	collection text NOT NULL,
+	period text NOT NULL,
+	bucket bigint NOT NULL,
+	role text NOT NULL,
+	address text NOT NULL,
+	PRIMARY KEY (chain_id, collection, period, bucket, role, address)
+);
@@ -0,0 +1,109 @@
+ALTER TABLE State ALTER COLUMN block TYPE integer;
+
+ALTER TABLE Block
+	ALTER COLUMN hash TYPE text USING hash::text,
+	ALTER COLUMN parent_hash TYPE text USING parent_hash::text;
+
+ALTER TABLE CollectionStatsParticipant
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN address TYPE text USING address::text;
+
+ALTER TABLE CollectionStats
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN native_tokens TYPE numeric,
+	ALTER COLUMN volume TYPE numeric,
+	ALTER COLUMN floor TYPE numeric;
+
+ALTER TABLE ERC721RarityStale
+	ALTER COLUMN collection TYPE text USING collection::text;
+
+ALTER TABLE ERC721Rarity
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text;
+
+ALTER TABLE ERC721TraitCount
+	ALTER COLUMN collection TYPE text USING collection::text;
+
+ALTER TABLE ERC721Attribute
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text;
+
+ALTER TABLE ERC721Metadata
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text;
+
+ALTER TABLE ERC1155Uri
+	ALTER COLUMN block_number TYPE text USING block_number::text,
+	ALTER COLUMN hash TYPE text USING hash::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN collection TYPE text USING collection::text;
+
+ALTER TABLE ERC1155Balance
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN holder TYPE text USING holder::text,
+	ALTER COLUMN balance TYPE numeric;
+
+ALTER TABLE ERC1155Tx
+	ALTER COLUMN timestamp TYPE text USING extract(epoch from timestamp)::bigint::text,
+	ALTER COLUMN block_number TYPE text USING block_number::text,
+	ALTER COLUMN hash TYPE text USING hash::text,
+	ALTER COLUMN operator TYPE text USING operator::text,
+	ALTER COLUMN from_addr TYPE text USING from_addr::text,
+	ALTER COLUMN to_addr TYPE text USING to_addr::text,
+	ALTER COLUMN value TYPE text USING value::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN amount TYPE numeric,
+	ALTER COLUMN collection TYPE text USING collection::text;
+
+ALTER TABLE ERC1155Token
+	ALTER COLUMN mint_timestamp TYPE text USING extract(epoch from mint_timestamp)::bigint::text,
+	ALTER COLUMN mint_block_number TYPE text USING mint_block_number::text,
+	ALTER COLUMN mint_hash TYPE text USING mint_hash::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN collection TYPE text USING collection::text;
+
+ALTER TABLE ERC1155Collection
+	ALTER COLUMN deploy_timestamp TYPE text USING extract(epoch from deploy_timestamp)::bigint::text,
+	ALTER COLUMN block_number TYPE text USING block_number::text,
+	ALTER COLUMN deploy_hash TYPE text USING COALESCE(deploy_hash::text, ''),
+	ALTER COLUMN contract_address TYPE text USING contract_address::text,
+	ALTER COLUMN deploy_hash SET NOT NULL;
+
+ALTER TABLE Sale
+	ALTER COLUMN timestamp TYPE text USING extract(epoch from timestamp)::bigint::text,
+	ALTER COLUMN block_number TYPE text USING block_number::text,
+	ALTER COLUMN hash TYPE text USING hash::text,
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN amount TYPE numeric,
+	ALTER COLUMN seller TYPE text USING seller::text,
+	ALTER COLUMN buyer TYPE text USING buyer::text,
+	ALTER COLUMN currency TYPE text USING currency::text,
+	ALTER COLUMN price TYPE numeric;
+
+ALTER TABLE ERC721Tx
+	ALTER COLUMN timestamp TYPE text USING extract(epoch from timestamp)::bigint::text,
+	ALTER COLUMN block_number TYPE text USING block_number::text,
+	ALTER COLUMN hash TYPE text USING hash::text,
+	ALTER COLUMN from_addr TYPE text USING from_addr::text,
+	ALTER COLUMN to_addr TYPE text USING to_addr::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN price TYPE numeric,
+	ALTER COLUMN currency TYPE text USING currency::text;
+
+ALTER TABLE ERC721
+	ALTER COLUMN mint_timestamp TYPE text USING extract(epoch from mint_timestamp)::bigint::text,
+	ALTER COLUMN mint_block_number TYPE text USING mint_block_number::text,
+	ALTER COLUMN mint_hash TYPE text USING mint_hash::text,
+	ALTER COLUMN token_id TYPE text USING token_id::text,
+	ALTER COLUMN collection TYPE text USING collection::text,
+	ALTER COLUMN owner TYPE text USING owner::text;
+
+ALTER TABLE ERC721Collection
+	ALTER COLUMN deploy_timestamp TYPE text USING extract(epoch from deploy_timestamp)::bigint::text,
+	ALTER COLUMN block_number TYPE text USING block_number::text,
+	ALTER COLUMN deploy_hash TYPE text USING COALESCE(deploy_hash::text, ''),
+	ALTER COLUMN contract_address TYPE text USING contract_address::text,
+	ALTER COLUMN deploy_hash SET NOT NULL;
@@ -0,0 +1,112 @@
+-- Typed columns: block numbers are bigint, times timestamptz, token ids and amounts numeric(78,0) which holds any uint256,
+-- addresses and hashes lowercase 0x prefixed hex of a fixed width. The deploy hash of a collection whose deployment is unknown is NULL
+
+ALTER TABLE ERC721Collection
+	ALTER COLUMN deploy_hash DROP NOT NULL,
+	ALTER COLUMN deploy_timestamp TYPE timestamptz USING to_timestamp(deploy_timestamp::bigint),
+	ALTER COLUMN block_number TYPE bigint USING block_number::bigint,
+	ALTER COLUMN deploy_hash TYPE char(66) USING NULLIF(lower(deploy_hash), ''),
+	ALTER COLUMN contract_address TYPE char(42) USING lower(contract_address);
+
+ALTER TABLE ERC721
+	ALTER COLUMN mint_timestamp TYPE timestamptz USING to_timestamp(mint_timestamp::bigint),
+	ALTER COLUMN mint_block_number TYPE bigint USING mint_block_number::bigint,
+	ALTER COLUMN mint_hash TYPE char(66) USING lower(mint_hash),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN owner TYPE char(42) USING lower(owner);
+
+ALTER TABLE ERC721Tx
+	ALTER COLUMN timestamp TYPE timestamptz USING to_timestamp(timestamp::bigint),
+	ALTER COLUMN block_number TYPE bigint USING block_number::bigint,
+	ALTER COLUMN hash TYPE char(66) USING lower(hash),
+	ALTER COLUMN from_addr TYPE char(42) USING lower(from_addr),
+	ALTER COLUMN to_addr TYPE char(42) USING lower(to_addr),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN price TYPE numeric(78,0),
+	ALTER COLUMN currency TYPE char(42) USING lower(currency);
+
+ALTER TABLE Sale
+	ALTER COLUMN timestamp TYPE timestamptz USING to_timestamp(timestamp::bigint),
+	ALTER COLUMN block_number TYPE bigint USING block_number::bigint,
+	ALTER COLUMN hash TYPE char(66) USING lower(hash),
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN amount TYPE numeric(78,0),
+	ALTER COLUMN seller TYPE char(42) USING lower(seller),
+	ALTER COLUMN buyer TYPE char(42) USING lower(buyer),
+	ALTER COLUMN currency TYPE char(42) USING lower(currency),
+	ALTER COLUMN price TYPE numeric(78,0);
+
+ALTER TABLE ERC1155Collection
+	ALTER COLUMN deploy_hash DROP NOT NULL,
+	ALTER COLUMN deploy_timestamp TYPE timestamptz USING to_timestamp(deploy_timestamp::bigint),
+	ALTER COLUMN block_number TYPE bigint USING block_number::bigint,
+	ALTER COLUMN deploy_hash TYPE char(66) USING NULLIF(lower(deploy_hash), ''),
+	ALTER COLUMN contract_address TYPE char(42) USING lower(contract_address);
+
+ALTER TABLE ERC1155Token
+	ALTER COLUMN mint_timestamp TYPE timestamptz USING to_timestamp(mint_timestamp::bigint),
+	ALTER COLUMN mint_block_number TYPE bigint USING mint_block_number::bigint,
+	ALTER COLUMN mint_hash TYPE char(66) USING lower(mint_hash),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN collection TYPE char(42) USING lower(collection);
+
+ALTER TABLE ERC1155Tx
+	ALTER COLUMN timestamp TYPE timestamptz USING to_timestamp(timestamp::bigint),
+	ALTER COLUMN block_number TYPE bigint USING block_number::bigint,
+	ALTER COLUMN hash TYPE char(66) USING lower(hash),
+	ALTER COLUMN operator TYPE char(42) USING lower(operator),
+	ALTER COLUMN from_addr TYPE char(42) USING lower(from_addr),
+	ALTER COLUMN to_addr TYPE char(42) USING lower(to_addr),
+	ALTER COLUMN value TYPE numeric(78,0) USING value::numeric,
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN amount TYPE numeric(78,0),
+	ALTER COLUMN collection TYPE char(42) USING lower(collection);
+
+ALTER TABLE ERC1155Balance
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN holder TYPE char(42) USING lower(holder),
+	ALTER COLUMN balance TYPE numeric(78,0);
+
+ALTER TABLE ERC1155Uri
+	ALTER COLUMN block_number TYPE bigint USING block_number::bigint,
+	ALTER COLUMN hash TYPE char(66) USING lower(hash),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric,
+	ALTER COLUMN collection TYPE char(42) USING lower(collection);
+
+ALTER TABLE ERC721Metadata
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric;
+
+ALTER TABLE ERC721Attribute
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric;
+
+ALTER TABLE ERC721TraitCount
+	ALTER COLUMN collection TYPE char(42) USING lower(collection);
+
+ALTER TABLE ERC721Rarity
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN token_id TYPE numeric(78,0) USING token_id::numeric;
+
+ALTER TABLE ERC721RarityStale
+	ALTER COLUMN collection TYPE char(42) USING lower(collection);
+
+ALTER TABLE CollectionStats
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN native_tokens TYPE numeric(78,0),
+	ALTER COLUMN volume TYPE numeric(78,0),
+	ALTER COLUMN floor TYPE numeric(78,0);
+
+ALTER TABLE CollectionStatsParticipant
+	ALTER COLUMN collection TYPE char(42) USING lower(collection),
+	ALTER COLUMN address TYPE char(42) USING lower(address);
+
+ALTER TABLE Block
+	ALTER COLUMN hash TYPE char(66) USING lower(hash),
+	ALTER COLUMN parent_hash TYPE char(66) USING lower(parent_hash);
+
+ALTER TABLE State ALTER COLUMN block TYPE bigint;
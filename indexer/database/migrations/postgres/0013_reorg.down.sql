@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ERC721Tx_chain_order_idx;
+DROP TABLE IF EXISTS Reorg;
@@ -0,0 +1,11 @@
+-- Every rollback of a chain, the streams of the API compare their cursor with the ancestors recorded after it
+CREATE TABLE IF NOT EXISTS Reorg (
+	id BIGSERIAL PRIMARY KEY,
+	chain_id BIGINT NOT NULL,
+	ancestor BIGINT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS Reorg_chain_id_idx ON Reorg(chain_id, id);
+
+-- The streams read the transfers of a chain in block order
+CREATE INDEX IF NOT EXISTS ERC721Tx_chain_order_idx ON ERC721Tx(chain_id, block_number, log_index);
@@ -0,0 +1,38 @@
+-- Outbound webhooks, the empty filters match every transfer. cursor is the position of the webhook in the transfer stream
+CREATE TABLE IF NOT EXISTS Webhook (
+	id BIGSERIAL PRIMARY KEY,
+	chain_id BIGINT NOT NULL,
+	url TEXT NOT NULL,
+	collection CHAR(42),
+	address CHAR(42),
+	tag TEXT,
+	secret TEXT NOT NULL,
+	active BOOLEAN NOT NULL,
+	cursor TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
+
+-- Events to send, pending until they are delivered or failed after the last attempt
+CREATE TABLE IF NOT EXISTS WebhookDelivery (
+	id BIGSERIAL PRIMARY KEY,
+	webhook_id BIGINT NOT NULL REFERENCES Webhook(id) ON DELETE CASCADE,
+	event TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	status TEXT NOT NULL,
+	attempts INTEGER NOT NULL,
+	next_attempt TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS WebhookDelivery_due_idx ON WebhookDelivery(status, next_attempt);
+CREATE INDEX IF NOT EXISTS WebhookDelivery_webhook_idx ON WebhookDelivery(webhook_id, id);
+
+-- Every request sent for a delivery, status_code is NULL when no response was received
+CREATE TABLE IF NOT EXISTS WebhookAttempt (
+	id BIGSERIAL PRIMARY KEY,
+	delivery_id BIGINT NOT NULL REFERENCES WebhookDelivery(id) ON DELETE CASCADE,
+	attempted_at TIMESTAMPTZ NOT NULL,
+	status_code INTEGER,
+	error TEXT,
+	duration_ms BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS WebhookAttempt_delivery_idx ON WebhookAttempt(delivery_id, id);
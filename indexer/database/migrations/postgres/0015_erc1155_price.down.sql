@@ -0,0 +1,5 @@
+ALTER TABLE ERC1155Tx DROP COLUMN IF EXISTS confidence;
+ALTER TABLE ERC1155Tx DROP COLUMN IF EXISTS price_source;
+ALTER TABLE ERC1155Tx DROP COLUMN IF EXISTS currency;
+ALTER TABLE ERC1155Tx DROP COLUMN IF EXISTS price;
+ALTER TABLE ERC1155Tx ADD COLUMN IF NOT EXISTS value numeric(78,0);
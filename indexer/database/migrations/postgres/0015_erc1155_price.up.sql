@@ -0,0 +1,7 @@
+-- Like for ERC-721, the raw value of the transaction is replaced by the price of one token of the row inferred from its payments.
+-- price is NULL when no payment was found, price_source is marketplace, native or erc20 and confidence high, medium or low
+ALTER TABLE ERC1155Tx DROP COLUMN IF EXISTS value;
+ALTER TABLE ERC1155Tx ADD COLUMN IF NOT EXISTS price numeric(78,0);
+ALTER TABLE ERC1155Tx ADD COLUMN IF NOT EXISTS currency char(42);
+ALTER TABLE ERC1155Tx ADD COLUMN IF NOT EXISTS price_source text;
+ALTER TABLE ERC1155Tx ADD COLUMN IF NOT EXISTS confidence text;
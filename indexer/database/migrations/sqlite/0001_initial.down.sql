@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS CollectionStatsParticipant;
+DROP TABLE IF EXISTS CollectionStats;
+DROP TABLE IF EXISTS Sale;
+DROP TABLE IF EXISTS ERC721RarityStale;
+DROP TABLE IF EXISTS ERC721Rarity;
+DROP TABLE IF EXISTS ERC721TraitCount;
+DROP TABLE IF EXISTS ERC721Attribute;
+DROP TABLE IF EXISTS ERC721Metadata;
+DROP TABLE IF EXISTS ERC1155Uri;
+DROP TABLE IF EXISTS ERC1155Balance;
+DROP TABLE IF EXISTS ERC1155Tx;
+DROP TABLE IF EXISTS ERC1155Token;
+DROP TABLE IF EXISTS ERC1155Collection;
+DROP TABLE IF EXISTS Block;
+DROP TABLE IF EXISTS State;
+DROP TABLE IF EXISTS ERC721Tx;
+DROP TABLE IF EXISTS ERC721;
+DROP TABLE IF EXISTS ERC721Collection;
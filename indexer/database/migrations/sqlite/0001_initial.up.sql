@@ -0,0 +1,256 @@
+-- Schema of the Postgres migrations up to 0011 for SQLite. Times are unix timestamps, token ids and uint256 amounts
+-- decimal text, addresses and hashes lowercase 0x prefixed hex. The deploy hash of a collection whose deployment is unknown is NULL
+
+CREATE TABLE IF NOT EXISTS ERC721Collection (
+	chain_id integer NOT NULL,
+	deploy_timestamp integer NOT NULL,
+	block_number integer NOT NULL,
+	deploy_hash text,
+	contract_address text NOT NULL,
+	contract_name text,
+	contract_symbol text,
+	PRIMARY KEY (chain_id, contract_address)
+);
+
+CREATE TABLE IF NOT EXISTS ERC721 (
+	chain_id integer NOT NULL,
+	mint_timestamp integer,
+	mint_block_number integer,
+	mint_hash text,
+	uri text,
+	token_id text NOT NULL,
+	collection text NOT NULL,
+	owner text,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721_owner_idx ON ERC721(chain_id, owner);
+
+-- price is NULL when no payment was found, price_source is marketplace, native or erc20 and confidence high, medium or low
+CREATE TABLE IF NOT EXISTS ERC721Tx (
+	id integer PRIMARY KEY,
+	chain_id integer NOT NULL,
+	timestamp integer,
+	block_number integer,
+	hash text,
+	log_index integer,
+	tag text,
+	from_addr text,
+	to_addr text,
+	token_id text,
+	collection text,
+	price text,
+	currency text,
+	price_source text,
+	confidence text
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ERC721Tx_hash_log_index_idx ON ERC721Tx(chain_id, hash, log_index);
+CREATE INDEX IF NOT EXISTS ERC721Tx_collection_idx ON ERC721Tx(chain_id, collection);
+CREATE INDEX IF NOT EXISTS ERC721Tx_from_idx ON ERC721Tx(chain_id, from_addr);
+CREATE INDEX IF NOT EXISTS ERC721Tx_to_idx ON ERC721Tx(chain_id, to_addr);
+CREATE INDEX IF NOT EXISTS ERC721Tx_token_id_and_collection_idx ON ERC721Tx(chain_id, collection, token_id);
+
+CREATE TABLE IF NOT EXISTS State (
+	chain_id integer NOT NULL PRIMARY KEY,
+	block integer NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Block (
+	chain_id integer NOT NULL,
+	number integer NOT NULL,
+	hash text NOT NULL,
+	parent_hash text NOT NULL,
+	PRIMARY KEY (chain_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS ERC1155Collection (
+	chain_id integer NOT NULL,
+	deploy_timestamp integer NOT NULL,
+	block_number integer NOT NULL,
+	deploy_hash text,
+	contract_address text NOT NULL,
+	contract_name text,
+	contract_symbol text,
+	PRIMARY KEY (chain_id, contract_address)
+);
+
+-- A token is recorded at its first mint, uri is read from the contract at that time
+CREATE TABLE IF NOT EXISTS ERC1155Token (
+	chain_id integer NOT NULL,
+	mint_timestamp integer,
+	mint_block_number integer,
+	mint_hash text,
+	uri text,
+	token_id text NOT NULL,
+	collection text NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+-- One row per transferred id, batch_index is the position of the id in a TransferBatch
+CREATE TABLE IF NOT EXISTS ERC1155Tx (
+	id integer PRIMARY KEY,
+	chain_id integer NOT NULL,
+	timestamp integer,
+	block_number integer,
+	hash text,
+	log_index integer,
+	batch_index integer,
+	tag text,
+	operator text,
+	from_addr text,
+	to_addr text,
+	value text,
+	token_id text,
+	amount text NOT NULL,
+	collection text
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ERC1155Tx_hash_log_index_idx ON ERC1155Tx(chain_id, hash, log_index, batch_index);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_collection_idx ON ERC1155Tx(chain_id, collection);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_from_idx ON ERC1155Tx(chain_id, from_addr);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_to_idx ON ERC1155Tx(chain_id, to_addr);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_token_id_and_collection_idx ON ERC1155Tx(chain_id, collection, token_id);
+
+CREATE TABLE IF NOT EXISTS ERC1155Balance (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	holder text NOT NULL,
+	balance text NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id, holder)
+);
+
+CREATE INDEX IF NOT EXISTS ERC1155Balance_holder_idx ON ERC1155Balance(chain_id, holder);
+
+-- URI events, the last one of a token replaces the uri read at mint
+CREATE TABLE IF NOT EXISTS ERC1155Uri (
+	chain_id integer NOT NULL,
+	block_number integer NOT NULL,
+	hash text NOT NULL,
+	log_index integer NOT NULL,
+	token_id text NOT NULL,
+	collection text NOT NULL,
+	uri text NOT NULL,
+	PRIMARY KEY (chain_id, hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS ERC1155Uri_token_id_and_collection_idx ON ERC1155Uri(chain_id, collection, token_id);
+
+-- Off-chain metadata of the ERC-721 tokens, status is pending, done or failed
+CREATE TABLE IF NOT EXISTS ERC721Metadata (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	uri text NOT NULL,
+	status text NOT NULL,
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt integer NOT NULL DEFAULT (unixepoch()),
+	last_error text,
+	name text,
+	description text,
+	image text,
+	attributes text,
+	document text,
+	fetched_at integer,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721Metadata_pending_idx ON ERC721Metadata(next_attempt) WHERE status = 'pending';
+
+-- Normalised attributes of the fetched metadata documents
+CREATE TABLE IF NOT EXISTS ERC721Attribute (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	trait_type text NOT NULL,
+	value text NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id, trait_type, value)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721Attribute_trait_idx ON ERC721Attribute(chain_id, collection, trait_type, value);
+
+-- Number of tokens of a collection having each trait, kept up to date with the attributes
+CREATE TABLE IF NOT EXISTS ERC721TraitCount (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	trait_type text NOT NULL,
+	value text NOT NULL,
+	count integer NOT NULL,
+	PRIMARY KEY (chain_id, collection, trait_type, value)
+);
+
+-- Rarity of the tokens, rank 1 is the rarest of its collection
+CREATE TABLE IF NOT EXISTS ERC721Rarity (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	score double precision NOT NULL,
+	trait_count integer NOT NULL,
+	rank integer NOT NULL,
+	PRIMARY KEY (chain_id, collection, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS ERC721Rarity_rank_idx ON ERC721Rarity(chain_id, collection, rank);
+
+-- Collections whose traits changed since their rarity was computed
+CREATE TABLE IF NOT EXISTS ERC721RarityStale (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	PRIMARY KEY (chain_id, collection)
+);
+
+-- NFTs sold on a marketplace, price is the share of the order paid for the tokens of the row, in the smallest unit of currency.
+-- currency is the zero address for the native currency
+CREATE TABLE IF NOT EXISTS Sale (
+	chain_id integer NOT NULL,
+	timestamp integer NOT NULL,
+	block_number integer NOT NULL,
+	hash text NOT NULL,
+	log_index integer NOT NULL,
+	item_index integer NOT NULL,
+	marketplace text NOT NULL,
+	collection text NOT NULL,
+	token_id text NOT NULL,
+	amount text NOT NULL,
+	seller text NOT NULL,
+	buyer text NOT NULL,
+	currency text NOT NULL,
+	price text NOT NULL,
+	PRIMARY KEY (chain_id, hash, log_index, item_index)
+);
+
+CREATE INDEX IF NOT EXISTS Sale_collection_idx ON Sale(chain_id, collection, timestamp);
+CREATE INDEX IF NOT EXISTS Sale_token_id_and_collection_idx ON Sale(chain_id, collection, token_id);
+
+-- Activity of a collection over an hour or a day, bucket is the unix timestamp of its start.
+-- volume, floor and native_tokens only count the sales paid in the native currency, holders is NULL for the past buckets
+-- of a rebuild since the owners at that time are not known
+CREATE TABLE IF NOT EXISTS CollectionStats (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	period text NOT NULL,
+	bucket integer NOT NULL,
+	transfers integer NOT NULL DEFAULT 0,
+	mints integer NOT NULL DEFAULT 0,
+	burns integer NOT NULL DEFAULT 0,
+	sales integer NOT NULL DEFAULT 0,
+	native_tokens text NOT NULL DEFAULT '0',
+	volume text NOT NULL DEFAULT '0',
+	floor text,
+	buyers integer NOT NULL DEFAULT 0,
+	sellers integer NOT NULL DEFAULT 0,
+	holders integer,
+	PRIMARY KEY (chain_id, collection, period, bucket)
+);
+
+-- Addresses that bought or sold in a bucket, they give the unique buyers and sellers of any window
+CREATE TABLE IF NOT EXISTS CollectionStatsParticipant (
+	chain_id integer NOT NULL,
+	collection text NOT NULL,
+	period text NOT NULL,
+	bucket integer NOT NULL,
+	role text NOT NULL,
+	address text NOT NULL,
+	PRIMARY KEY (chain_id, collection, period, bucket, role, address)
+);
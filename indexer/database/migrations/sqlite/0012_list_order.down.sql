@@ -0,0 +1,26 @@
+DROP INDEX IF EXISTS ERC1155Balance_holder_order_idx;
+CREATE INDEX IF NOT EXISTS ERC1155Balance_holder_idx ON ERC1155Balance(chain_id, holder);
+
+DROP INDEX IF EXISTS ERC1155Token_collection_order_idx;
+
+DROP INDEX IF EXISTS ERC1155Tx_collection_order_idx;
+DROP INDEX IF EXISTS ERC1155Tx_from_order_idx;
+DROP INDEX IF EXISTS ERC1155Tx_to_order_idx;
+DROP INDEX IF EXISTS ERC1155Tx_token_id_order_idx;
+CREATE INDEX IF NOT EXISTS ERC1155Tx_collection_idx ON ERC1155Tx(chain_id, collection);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_from_idx ON ERC1155Tx(chain_id, from_addr);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_to_idx ON ERC1155Tx(chain_id, to_addr);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_token_id_and_collection_idx ON ERC1155Tx(chain_id, collection, token_id);
+
+DROP INDEX IF EXISTS ERC721_collection_order_idx;
+DROP INDEX IF EXISTS ERC721_owner_order_idx;
+CREATE INDEX IF NOT EXISTS ERC721_owner_idx ON ERC721(chain_id, owner);
+
+DROP INDEX IF EXISTS ERC721Tx_collection_order_idx;
+DROP INDEX IF EXISTS ERC721Tx_from_order_idx;
+DROP INDEX IF EXISTS ERC721Tx_to_order_idx;
+DROP INDEX IF EXISTS ERC721Tx_token_id_order_idx;
+CREATE INDEX IF NOT EXISTS ERC721Tx_collection_idx ON ERC721Tx(chain_id, collection);
+CREATE INDEX IF NOT EXISTS ERC721Tx_from_idx ON ERC721Tx(chain_id, from_addr);
+CREATE INDEX IF NOT EXISTS ERC721Tx_to_idx ON ERC721Tx(chain_id, to_addr);
+CREATE INDEX IF NOT EXISTS ERC721Tx_token_id_and_collection_idx ON ERC721Tx(chain_id, collection, token_id);
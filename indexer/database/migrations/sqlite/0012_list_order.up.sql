@@ -0,0 +1,29 @@
+-- The API lists the transfers by block and log index and the tokens by mint block, the indexes give these orders
+-- to the filters they are listed by and replace the ones that were a prefix of them
+
+DROP INDEX IF EXISTS ERC721Tx_collection_idx;
+DROP INDEX IF EXISTS ERC721Tx_from_idx;
+DROP INDEX IF EXISTS ERC721Tx_to_idx;
+DROP INDEX IF EXISTS ERC721Tx_token_id_and_collection_idx;
+CREATE INDEX IF NOT EXISTS ERC721Tx_collection_order_idx ON ERC721Tx(chain_id, collection, block_number, log_index);
+CREATE INDEX IF NOT EXISTS ERC721Tx_from_order_idx ON ERC721Tx(chain_id, from_addr, block_number, log_index);
+CREATE INDEX IF NOT EXISTS ERC721Tx_to_order_idx ON ERC721Tx(chain_id, to_addr, block_number, log_index);
+CREATE INDEX IF NOT EXISTS ERC721Tx_token_id_order_idx ON ERC721Tx(chain_id, collection, token_id, block_number, log_index);
+
+DROP INDEX IF EXISTS ERC721_owner_idx;
+CREATE INDEX IF NOT EXISTS ERC721_owner_order_idx ON ERC721(chain_id, owner, mint_block_number, collection, token_id);
+CREATE INDEX IF NOT EXISTS ERC721_collection_order_idx ON ERC721(chain_id, collection, mint_block_number, token_id);
+
+DROP INDEX IF EXISTS ERC1155Tx_collection_idx;
+DROP INDEX IF EXISTS ERC1155Tx_from_idx;
+DROP INDEX IF EXISTS ERC1155Tx_to_idx;
+DROP INDEX IF EXISTS ERC1155Tx_token_id_and_collection_idx;
+CREATE INDEX IF NOT EXISTS ERC1155Tx_collection_order_idx ON ERC1155Tx(chain_id, collection, block_number, log_index, batch_index);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_from_order_idx ON ERC1155Tx(chain_id, from_addr, block_number, log_index, batch_index);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_to_order_idx ON ERC1155Tx(chain_id, to_addr, block_number, log_index, batch_index);
+CREATE INDEX IF NOT EXISTS ERC1155Tx_token_id_order_idx ON ERC1155Tx(chain_id, collection, token_id, block_number, log_index, batch_index);
+
+CREATE INDEX IF NOT EXISTS ERC1155Token_collection_order_idx ON ERC1155Token(chain_id, collection, mint_block_number, token_id);
+
+DROP INDEX IF EXISTS ERC1155Balance_holder_idx;
+CREATE INDEX IF NOT EXISTS ERC1155Balance_holder_order_idx ON ERC1155Balance(chain_id, holder, collection, token_id);
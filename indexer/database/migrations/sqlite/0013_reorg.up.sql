@@ -0,0 +1,12 @@
+-- Every rollback of a chain, the streams of the API compare their cursor with the ancestors recorded after it.
+-- AUTOINCREMENT never reuses the id of a deleted row
+CREATE TABLE IF NOT EXISTS Reorg (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	chain_id integer NOT NULL,
+	ancestor integer NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS Reorg_chain_id_idx ON Reorg(chain_id, id);
+
+-- The streams read the transfers of a chain in block order
+CREATE INDEX IF NOT EXISTS ERC721Tx_chain_order_idx ON ERC721Tx(chain_id, block_number, log_index);
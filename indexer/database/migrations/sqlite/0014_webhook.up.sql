@@ -0,0 +1,38 @@
+-- Outbound webhooks, the empty filters match every transfer. cursor is the position of the webhook in the transfer stream
+CREATE TABLE IF NOT EXISTS Webhook (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	chain_id integer NOT NULL,
+	url text NOT NULL,
+	collection text,
+	address text,
+	tag text,
+	secret text NOT NULL,
+	active boolean NOT NULL,
+	cursor text NOT NULL,
+	created_at integer NOT NULL
+);
+
+-- Events to send, pending until they are delivered or failed after the last attempt
+CREATE TABLE IF NOT EXISTS WebhookDelivery (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	webhook_id integer NOT NULL REFERENCES Webhook(id) ON DELETE CASCADE,
+	event text NOT NULL,
+	payload text NOT NULL,
+	status text NOT NULL,
+	attempts integer NOT NULL,
+	next_attempt integer NOT NULL,
+	created_at integer NOT NULL
+);
+CREATE INDEX IF NOT EXISTS WebhookDelivery_due_idx ON WebhookDelivery(status, next_attempt);
+CREATE INDEX IF NOT EXISTS WebhookDelivery_webhook_idx ON WebhookDelivery(webhook_id, id);
+
+-- Every request sent for a delivery, status_code is NULL when no response was received
+CREATE TABLE IF NOT EXISTS WebhookAttempt (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	delivery_id integer NOT NULL REFERENCES WebhookDelivery(id) ON DELETE CASCADE,
+	attempted_at integer NOT NULL,
+	status_code integer,
+	error text,
+	duration_ms integer NOT NULL
+);
+CREATE INDEX IF NOT EXISTS WebhookAttempt_delivery_idx ON WebhookAttempt(delivery_id, id);
@@ -0,0 +1,5 @@
+ALTER TABLE ERC1155Tx DROP COLUMN confidence;
+ALTER TABLE ERC1155Tx DROP COLUMN price_source;
+ALTER TABLE ERC1155Tx DROP COLUMN currency;
+ALTER TABLE ERC1155Tx DROP COLUMN price;
+ALTER TABLE ERC1155Tx ADD COLUMN value text;
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Page of a list: at most Limit rows after the row of Cursor, the first rows without it
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Key of the last row of a page, given to the clients as base64 JSON.
+// Kind is the list it was made for and the other fields are the columns that list is ordered by
+type cursor struct {
+	Kind       string `json:"k"`
+	Block      uint64 `json:"b,omitempty"`
+	LogIndex   uint   `json:"l,omitempty"`
+	BatchIndex int    `json:"i,omitempty"`
+	// Nil for the NFTs without rarity
+	Rank       *int64 `json:"r,omitempty"`
+	Collection string `json:"c,omitempty"`
+	TokenId    string `json:"t,omitempty"`
+	// Last reorganisation seen by a stream
+	Reorg int64 `json:"g,omitempty"`
+	// Id of the lists of rows with a serial key
+	Id int64 `json:"n,omitempty"`
+}
+
+func (c cursor) encode() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+var tokenIdPattern = regexp.MustCompile(`^[0-9]{1,78}$`)
+
+// Decode the cursor of a page of a kind of list, first is true without a cursor
+func (p Page) after(kind string) (c cursor, first bool, err error) {
+	if p.Cursor == "" {
+		return c, true, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err == nil {
+		err = json.Unmarshal(decoded, &c)
+	}
+	if err != nil || c.Kind != kind || (c.Collection != "" && !common.IsHexAddress(c.Collection)) || (c.TokenId != "" && !tokenIdPattern.MatchString(c.TokenId)) {
+		return c, false, ErrInvalidCursor
+	}
+	c.Collection = strings.ToLower(c.Collection)
+	return c, false, nil
+}
+
+// Keep the rows of a page, next is the cursor of its last row when more rows follow
+func pageRows[T any](rows []T, limit int, key func(row T) cursor) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+	rows = rows[:limit]
+	return rows, key(rows[limit-1]).encode()
+}
+
+// Query built with its arguments
+type listQuery struct {
+	sql  string
+	args []any
+}
+
+// Placeholder of a new argument
+func (q *listQuery) arg(value any) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// Fetch one row more than the page to know whether another one follows
+func (q *listQuery) limit(page Page) {
+	q.sql += ` LIMIT ` + q.arg(page.Limit+1)
+}
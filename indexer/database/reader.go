@@ -0,0 +1,663 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"workspace/customTypes"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Transfers matching every non empty field, Address is the sender or the receiver
+type TransferFilter struct {
+	ChainId    uint64
+	Collection string
+	TokenId    string
+	Address    string
+	Tag        string
+}
+
+// Query of the rows of a table matching the filter
+func (f TransferFilter) query(columns string, table string) *listQuery {
+	q := &listQuery{}
+	q.sql = `SELECT ` + columns + ` FROM ` + table + ` WHERE chain_id = ` + q.arg(f.ChainId)
+	if f.Collection != "" {
+		q.sql += ` AND collection = ` + q.arg(strings.ToLower(f.Collection))
+	}
+	if f.TokenId != "" {
+		q.sql += ` AND token_id = ` + q.arg(f.TokenId)
+	}
+	if f.Address != "" {
+		address := q.arg(strings.ToLower(f.Address))
+		q.sql += ` AND (from_addr = ` + address + ` OR to_addr = ` + address + `)`
+	}
+	if f.Tag != "" {
+		q.sql += ` AND tag = ` + q.arg(f.Tag)
+	}
+	return q
+}
+
+// NFTs matching every non empty field. Traits keeps the NFTs having one of the values of every trait type
+type NFTFilter struct {
+	ChainId    uint64
+	Collection string
+	Owner      string
+	Traits     map[string][]string
+	// Rarest first instead of the last minted first
+	ByRarity bool
+}
+
+// NFT of a batched read
+type TokenKey struct {
+	Collection string
+	TokenId    string
+}
+
+// ///////////////////////////////////// ERC-721 ///////////////////////////////////////
+// Times are returned as unix timestamps
+func txColumns(d dialect) string {
+	return `chain_id, ` + d.epoch("timestamp") + `, block_number, hash, log_index, tag, from_addr, to_addr, token_id, collection, price, currency, price_source, confidence`
+}
+
+func nftColumns(d dialect) string {
+	return `n.chain_id, ` + d.epoch("n.mint_timestamp") + `, n.mint_block_number, n.mint_hash, n.uri, n.token_id, n.collection, n.owner, r.score, r.rank`
+}
+
+const rarityJoin = ` LEFT JOIN ERC721Rarity r ON r.chain_id = n.chain_id AND r.collection = n.collection AND r.token_id = n.token_id`
+
+const metadataJoin = ` LEFT JOIN ERC721Metadata m ON m.chain_id = n.chain_id AND m.collection = n.collection AND m.token_id = n.token_id`
+
+// Get a page of the transfers matching filter, the last first
+func (s *sqlStore) SelectTransfers(ctx context.Context, filter TransferFilter, page Page) (txs []customTypes.ERC721TxStruct, next string, err error) {
+	after, first, err := page.after("transfer")
+	if err != nil {
+		return nil, "", err
+	}
+	q := filter.query(txColumns(s), "ERC721Tx")
+	if !first {
+		q.sql += ` AND (block_number, log_index) < (` + q.arg(after.Block) + `, ` + q.arg(after.LogIndex) + `)`
+	}
+	q.sql += ` ORDER BY block_number DESC, log_index DESC`
+	q.limit(page)
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	txs, err = scanTransfers(rows)
+	if err != nil {
+		return nil, "", err
+	}
+	txs, next = pageRows(txs, page.Limit, func(tx customTypes.ERC721TxStruct) cursor {
+		return cursor{Kind: "transfer", Block: tx.BlockNumber, LogIndex: tx.LogIndex}
+	})
+	return txs, next, nil
+}
+
+// Read the rows of a query of txColumns
+func scanTransfers(rows *sql.Rows) ([]customTypes.ERC721TxStruct, error) {
+	txs := []customTypes.ERC721TxStruct{}
+	for rows.Next() {
+		var tx customTypes.ERC721TxStruct
+		var price, priceSource, confidence sql.NullString
+		err := rows.Scan(&tx.ChainId, &tx.Timestamp, &tx.BlockNumber, &tx.TxHash, &tx.LogIndex, &tx.Tag, (*addressColumn)(&tx.FromAddr), (*addressColumn)(&tx.ToAddr),
+			&tx.TokenId, (*addressColumn)(&tx.Collection), &price, (*addressColumn)(&tx.Currency), &priceSource, &confidence)
+		if err != nil {
+			return nil, err
+		}
+		tx.Price = price.String
+		tx.PriceSource = priceSource.String
+		tx.Confidence = confidence.String
+		txs = append(txs, tx)
+	}
+	return txs, rows.Err()
+}
+
+// Get a page of the NFTs matching filter with their rarity, the last minted or the rarest first
+func (s *sqlStore) SelectNFTs(ctx context.Context, filter NFTFilter, page Page) (nfts []customTypes.NFTStruct, next string, err error) {
+	kind := "nft"
+	if filter.ByRarity {
+		kind = "nft-rarity"
+	}
+	after, first, err := page.after(kind)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := &listQuery{}
+	q.sql = `SELECT ` + nftColumns(s) + ` FROM ERC721 n` + rarityJoin + ` WHERE n.chain_id = ` + q.arg(filter.ChainId)
+	if filter.Collection != "" {
+		q.sql += ` AND n.collection = ` + q.arg(strings.ToLower(filter.Collection))
+	}
+	if filter.Owner != "" {
+		q.sql += ` AND n.owner = ` + q.arg(strings.ToLower(filter.Owner))
+	}
+	q.withTraits(filter.Traits)
+
+	// The NFTs without rarity come last
+	switch {
+	case first:
+	case !filter.ByRarity:
+		q.sql += ` AND (n.mint_block_number, n.collection, ` + s.uintKey("n.token_id", "") + `) < (` + q.arg(after.Block) + `, ` + q.arg(after.Collection) + `, ` + s.uintKey(q.arg(after.TokenId), "") + `)`
+	case after.Rank == nil:
+		q.sql += ` AND r.rank IS NULL AND (n.collection, ` + s.uintKey("n.token_id", "") + `) > (` + q.arg(after.Collection) + `, ` + s.uintKey(q.arg(after.TokenId), "") + `)`
+	default:
+		rank := q.arg(*after.Rank)
+		q.sql += ` AND (r.rank > ` + rank + ` OR r.rank IS NULL OR (r.rank = ` + rank + ` AND (n.collection, ` + s.uintKey("n.token_id", "") + `) > (` + q.arg(after.Collection) + `, ` + s.uintKey(q.arg(after.TokenId), "") + `)))`
+	}
+	if filter.ByRarity {
+		q.sql += ` ORDER BY r.rank ASC NULLS LAST, n.collection, ` + s.uintKey("n.token_id", "")
+	} else {
+		q.sql += ` ORDER BY n.mint_block_number DESC, n.collection DESC, ` + s.uintKey("n.token_id", " DESC")
+	}
+	q.limit(page)
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	nfts = []customTypes.NFTStruct{}
+	for rows.Next() {
+		var nft customTypes.NFTStruct
+		var rarity nullRarity
+		err = rows.Scan(append(nftFields(&nft), rarity.fields()...)...)
+		if err != nil {
+			return nil, "", err
+		}
+		nft.Rarity = rarity.value()
+		nfts = append(nfts, nft)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+	nfts, next = pageRows(nfts, page.Limit, func(nft customTypes.NFTStruct) cursor {
+		key := cursor{Kind: kind, Block: nft.MintBlockNumber, Collection: strings.ToLower(nft.Collection.Hex()), TokenId: nft.TokenId}
+		if filter.ByRarity {
+			key.Block = 0
+			if nft.Rarity != nil {
+				key.Rank = &nft.Rarity.Rank
+			}
+		}
+		return key
+	})
+	return nfts, next, nil
+}
+
+// Get an NFT with its rarity and metadata
+func (s *sqlStore) SelectNFT(ctx context.Context, chainId uint64, collection string, tokenId string) (nft customTypes.NFTStruct, found bool, err error) {
+	nfts, err := s.selectNFTs(ctx, `SELECT `+nftColumns(s)+`, `+metadataColumns(s)+` FROM ERC721 n`+rarityJoin+metadataJoin+`
+	WHERE n.chain_id = $1 AND n.collection = $2 AND n.token_id = $3`, chainId, strings.ToLower(collection), tokenId)
+	if err != nil || len(nfts) == 0 {
+		return nft, false, err
+	}
+	return nfts[0], true, nil
+}
+
+// Get the NFTs of a chain among keys with their rarity and metadata, the unknown ones are left out
+func (s *sqlStore) SelectNFTsByKey(ctx context.Context, chainId uint64, keys []TokenKey) ([]customTypes.NFTStruct, error) {
+	if len(keys) == 0 {
+		return []customTypes.NFTStruct{}, nil
+	}
+	q := &listQuery{}
+	q.sql = `SELECT ` + nftColumns(s) + `, ` + metadataColumns(s) + ` FROM ERC721 n` + rarityJoin + metadataJoin + `
+	WHERE n.chain_id = ` + q.arg(chainId) + ` AND (n.collection, n.token_id) IN (`
+	for i, key := range keys {
+		if i > 0 {
+			q.sql += `, `
+		}
+		q.sql += `(` + q.arg(strings.ToLower(key.Collection)) + `, ` + q.arg(key.TokenId) + `)`
+	}
+	q.sql += `)`
+	return s.selectNFTs(ctx, q.sql, q.args...)
+}
+
+// Rows of nftColumns followed by metadataColumns
+func (s *sqlStore) selectNFTs(ctx context.Context, query string, args ...any) (nfts []customTypes.NFTStruct, err error) {
+	rows, err := s.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nfts = []customTypes.NFTStruct{}
+	for rows.Next() {
+		var nft customTypes.NFTStruct
+		var rarity nullRarity
+		var metadata nullMetadata
+		err = rows.Scan(append(append(nftFields(&nft), rarity.fields()...), metadata.fields()...)...)
+		if err != nil {
+			return nil, err
+		}
+		nft.Rarity = rarity.value()
+		nft.Metadata = metadata.value()
+		nfts = append(nfts, nft)
+	}
+	return nfts, rows.Err()
+}
+
+// Get the collections of a chain among addresses, the unknown ones are left out
+func (s *sqlStore) SelectCollections(ctx context.Context, chainId uint64, addresses []string) (collections []customTypes.ERC721CollectionStruct, err error) {
+	collections = []customTypes.ERC721CollectionStruct{}
+	if len(addresses) == 0 {
+		return collections, nil
+	}
+	q := &listQuery{}
+	placeholders := make([]string, len(addresses))
+	for i, address := range addresses {
+		placeholders[i] = q.arg(strings.ToLower(address))
+	}
+	q.sql = `SELECT chain_id, contract_address, COALESCE(contract_name, ''), COALESCE(contract_symbol, ''), ` + s.epoch("deploy_timestamp") + `, block_number, COALESCE(deploy_hash, '')
+	FROM ERC721Collection WHERE chain_id = ` + q.arg(chainId) + ` AND contract_address IN (` + strings.Join(placeholders, ", ") + `)`
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var collection customTypes.ERC721CollectionStruct
+		err = rows.Scan(&collection.ChainId, (*addressColumn)(&collection.ContractAddress), &collection.ContractName, &collection.ContractSymbol,
+			&collection.DeployTimestamp, &collection.DeployBlockNumber, &collection.DeployTxHash)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	return collections, rows.Err()
+}
+
+// Destinations of the columns of nftColumns before the rarity
+func nftFields(nft *customTypes.NFTStruct) []any {
+	return []any{&nft.ChainId, &nft.MintTimestamp, &nft.MintBlockNumber, &nft.MintTxHash, &nft.URI, &nft.TokenId, (*addressColumn)(&nft.Collection), (*addressColumn)(&nft.Owner)}
+}
+
+// Scan destination of an address stored as text, NULL is the zero address
+type addressColumn common.Address
+
+func (a *addressColumn) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		*a = addressColumn(common.HexToAddress(v))
+	case []byte:
+		*a = addressColumn(common.HexToAddress(string(v)))
+	default:
+		*a = addressColumn{}
+	}
+	return nil
+}
+
+// Rarity columns of a LEFT JOIN, NULL until the rarity of the collection is computed
+type nullRarity struct {
+	score sql.NullFloat64
+	rank  sql.NullInt64
+}
+
+func (r *nullRarity) fields() []any {
+	return []any{&r.score, &r.rank}
+}
+
+func (r *nullRarity) value() *customTypes.RarityStruct {
+	if !r.score.Valid || !r.rank.Valid {
+		return nil
+	}
+	return &customTypes.RarityStruct{Score: r.score.Float64, Rank: r.rank.Int64}
+}
+
+func metadataColumns(d dialect) string {
+	return `m.status, m.name, m.description, m.image, m.attributes, ` + d.epoch("m.fetched_at") + `, m.last_error`
+}
+
+// Metadata columns of a LEFT JOIN, all NULL when the document was never fetched
+type nullMetadata struct {
+	status      sql.NullString
+	name        sql.NullString
+	description sql.NullString
+	image       sql.NullString
+	attributes  []byte
+	fetchedAt   sql.NullInt64
+	lastError   sql.NullString
+}
+
+func (m *nullMetadata) fields() []any {
+	return []any{&m.status, &m.name, &m.description, &m.image, &m.attributes, &m.fetchedAt, &m.lastError}
+}
+
+func (m *nullMetadata) value() *customTypes.NFTMetadataStruct {
+	if !m.status.Valid {
+		return nil
+	}
+	metadata := &customTypes.NFTMetadataStruct{
+		Status:      m.status.String,
+		Name:        m.name.String,
+		Description: m.description.String,
+		Image:       m.image.String,
+		Attributes:  json.RawMessage(m.attributes),
+		LastError:   m.lastError.String,
+	}
+	if m.fetchedAt.Valid {
+		fetchedAt := uint64(m.fetchedAt.Int64)
+		metadata.FetchedAt = &fetchedAt
+	}
+	return metadata
+}
+
+// Add a condition per trait type to a query on ERC721 n.
+// Several values of a trait type match any of them, different trait types must all match.
+func (q *listQuery) withTraits(traits map[string][]string) {
+	traitTypes := make([]string, 0, len(traits))
+	for traitType := range traits {
+		traitTypes = append(traitTypes, traitType)
+	}
+	sort.Strings(traitTypes)
+	for _, traitType := range traitTypes {
+		q.sql += ` AND EXISTS (
+		SELECT 1 FROM ERC721Attribute a WHERE a.chain_id = n.chain_id AND a.collection = n.collection AND a.token_id = n.token_id
+		AND a.trait_type = ` + q.arg(traitType)
+		values := make([]string, len(traits[traitType]))
+		for i, value := range traits[traitType] {
+			values[i] = q.arg(value)
+		}
+		q.sql += ` AND a.value IN (` + strings.Join(values, ", ") + `)
+	)`
+	}
+}
+
+// Get the traits of a collection with the number of NFTs having each of them
+func (s *sqlStore) SelectTraitCounts(ctx context.Context, chainId uint64, collection string) (traits []customTypes.TraitCountStruct, err error) {
+	rows, err := s.query(ctx, `SELECT trait_type, value, count FROM ERC721TraitCount WHERE chain_id = $1 AND collection = $2 ORDER BY trait_type, count DESC, value`, chainId, strings.ToLower(collection))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	traits = []customTypes.TraitCountStruct{}
+	for rows.Next() {
+		var trait customTypes.TraitCountStruct
+		err = rows.Scan(&trait.TraitType, &trait.Value, &trait.Count)
+		if err != nil {
+			return nil, err
+		}
+		traits = append(traits, trait)
+	}
+	return traits, rows.Err()
+}
+
+// Ask the metadata worker to fetch the document of an NFT again, found is false for an unknown NFT
+func (s *sqlStore) RefreshMetadata(ctx context.Context, chainId uint64, collection string, tokenId string) (found bool, err error) {
+	now := s.time("$4")
+	refreshQuery := `INSERT INTO ERC721Metadata(chain_id, collection, token_id, uri, status, attempts, next_attempt)
+	SELECT chain_id, collection, token_id, uri, 'pending', 0, ` + now + ` FROM ERC721 WHERE chain_id = $1 AND collection = $2 AND token_id = $3
+	ON CONFLICT (chain_id, collection, token_id) DO UPDATE SET uri = EXCLUDED.uri, status = 'pending', attempts = 0, next_attempt = ` + now + `, last_error = NULL`
+	result, err := s.exec(ctx, refreshQuery, chainId, strings.ToLower(collection), tokenId, time.Now().Unix())
+	if err != nil {
+		return false, err
+	}
+	refreshed, err := result.RowsAffected()
+	return refreshed > 0, err
+}
+
+// ///////////////////////////////////// STATS ///////////////////////////////////////
+// Get the activity of a collection since a unix timestamp from the buckets of period, since is the start of a bucket
+func (s *sqlStore) SelectCollectionWindow(ctx context.Context, chainId uint64, collection string, period string, since int64) (window customTypes.CollectionWindowStruct, err error) {
+	collection = strings.ToLower(collection)
+	windowQuery := `SELECT COALESCE(SUM(transfers), 0), COALESCE(SUM(mints), 0), COALESCE(SUM(burns), 0), COALESCE(SUM(sales), 0),
+	COALESCE(` + s.sum("volume") + `, 0), ` + s.min("floor") + `, ` + s.div(s.sum("volume"), "NULLIF("+s.sum("native_tokens")+", '0')") + `
+	FROM CollectionStats WHERE chain_id = $1 AND collection = $2 AND period = $3 AND bucket >= $4`
+	const participantsQuery = `SELECT COUNT(DISTINCT address) FILTER (WHERE role = 'buyer'), COUNT(DISTINCT address) FILTER (WHERE role = 'seller')
+	FROM CollectionStatsParticipant WHERE chain_id = $1 AND collection = $2 AND period = $3 AND bucket >= $4`
+	const holdersQuery = `SELECT holders FROM CollectionStats WHERE chain_id = $1 AND collection = $2 AND period = 'hour' AND holders IS NOT NULL ORDER BY bucket DESC LIMIT 1`
+
+	err = s.queryRow(ctx, `SELECT COUNT(DISTINCT owner) FROM ERC721 WHERE chain_id = $1 AND collection = $2`, chainId, collection).Scan(&window.OwnerCount)
+	if err != nil {
+		return window, err
+	}
+	stats := &window.CollectionStatsStruct
+	err = s.queryRow(ctx, windowQuery, chainId, collection, period, since).Scan(&stats.Transfers, &stats.Mints, &stats.Burns, &stats.SaleCount, &stats.Volume, &stats.Floor, &stats.AveragePrice)
+	if err != nil {
+		return window, err
+	}
+	window.TxCount = stats.Transfers + stats.Mints + stats.Burns
+	err = s.queryRow(ctx, participantsQuery, chainId, collection, period, since).Scan(&stats.Buyers, &stats.Sellers)
+	if err != nil {
+		return window, err
+	}
+	err = s.queryRow(ctx, holdersQuery, chainId, collection).Scan(&stats.Holders)
+	if err != nil && err != sql.ErrNoRows {
+		return window, err
+	}
+
+	window.Currencies, err = s.selectSalesSince(ctx, chainId, collection, since)
+	return window, err
+}
+
+// Prices are per token so bundles and ERC-1155 sales compare with single sales.
+// The transfers priced from their payments count as sales unless the payment could not be attributed to the buyer
+func (s *sqlStore) selectSalesSince(ctx context.Context, chainId uint64, collection string, since int64) (currencies []customTypes.SaleStatsStruct, err error) {
+	salesSinceQuery := `SELECT currency, COUNT(*), ` + s.sum("price") + `, ` + s.min(s.div("price", "amount")) + `, ` + s.div(s.sum("price"), s.sum("amount")) + ` FROM (
+		SELECT currency, price, amount FROM Sale WHERE chain_id = $1 AND collection = $2 AND timestamp >= ` + s.time("$3") + `
+		UNION ALL
+		SELECT currency, price, 1 FROM ERC721Tx WHERE chain_id = $1 AND collection = $2 AND timestamp >= ` + s.time("$3") + `
+		AND tag = 'transfer' AND price_source IN ('native', 'erc20') AND confidence IN ('high', 'medium')
+	) sales GROUP BY currency ORDER BY currency`
+	rows, err := s.query(ctx, salesSinceQuery, chainId, collection, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	currencies = []customTypes.SaleStatsStruct{}
+	for rows.Next() {
+		var sales customTypes.SaleStatsStruct
+		err = rows.Scan(&sales.Currency, &sales.SaleCount, &sales.Volume, &sales.Floor, &sales.AveragePrice)
+		if err != nil {
+			return nil, err
+		}
+		currencies = append(currencies, sales)
+	}
+	return currencies, rows.Err()
+}
+
+// Get the stats of a collection for every bucket of size seconds between from and to, the buckets without activity are included
+func (s *sqlStore) SelectStatsSeries(ctx context.Context, chainId uint64, collection string, period string, size int64, from int64, to int64) (points []customTypes.StatsPointStruct, err error) {
+	// Daily buyers and sellers come from the daily participants, they are not the sum of the hourly ones
+	seriesQuery := `SELECT bucket, transfers, mints, burns, sales, buyers, sellers, holders, volume, floor, ` + s.div("volume", "NULLIF(native_tokens, '0')") + `
+	FROM CollectionStats WHERE chain_id = $1 AND collection = $2 AND period = $3 AND bucket >= $4 AND bucket <= $5
+	ORDER BY bucket`
+	rows, err := s.query(ctx, seriesQuery, chainId, strings.ToLower(collection), period, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points = []customTypes.StatsPointStruct{}
+	next := from
+	for rows.Next() {
+		var point customTypes.StatsPointStruct
+		err = rows.Scan(&point.Bucket, &point.Transfers, &point.Mints, &point.Burns, &point.SaleCount, &point.Buyers, &point.Sellers,
+			&point.Holders, &point.Volume, &point.Floor, &point.AveragePrice)
+		if err != nil {
+			return nil, err
+		}
+		points = appendEmptyPoints(points, next, point.Bucket, size)
+		points = append(points, point)
+		next = point.Bucket + size
+	}
+	return appendEmptyPoints(points, next, to+size, size), rows.Err()
+}
+
+// Add the buckets from from included to to excluded
+func appendEmptyPoints(points []customTypes.StatsPointStruct, from int64, to int64, size int64) []customTypes.StatsPointStruct {
+	for bucket := from; bucket < to; bucket += size {
+		points = append(points, customTypes.StatsPointStruct{Bucket: bucket, CollectionStatsStruct: customTypes.CollectionStatsStruct{Volume: "0"}})
+	}
+	return points
+}
+
+// ///////////////////////////////////// ERC-1155 ///////////////////////////////////////
+func erc1155TxColumns(d dialect) string {
+	return `chain_id, ` + d.epoch("timestamp") + `, block_number, hash, log_index, batch_index, tag, operator, from_addr, to_addr, token_id, amount, collection, price, currency, price_source, confidence`
+}
+
+// The last URI event of a token replaces the uri read at mint
+const erc1155UriColumn = `COALESCE((
+	SELECT u.uri FROM ERC1155Uri u WHERE u.chain_id = t.chain_id AND u.collection = t.collection AND u.token_id = t.token_id
+	ORDER BY u.block_number DESC, u.log_index DESC LIMIT 1
+), t.uri, '')`
+
+func erc1155TokenColumns(d dialect) string {
+	return `t.chain_id, ` + d.epoch("t.mint_timestamp") + `, t.mint_block_number, t.mint_hash, ` + erc1155UriColumn + `, t.token_id, t.collection,
+	(SELECT COALESCE(` + d.sum("b.balance") + `, 0) FROM ERC1155Balance b WHERE b.chain_id = t.chain_id AND b.collection = t.collection AND b.token_id = t.token_id)`
+}
+
+// Get a page of the ERC-1155 transfers matching filter, the last first
+func (s *sqlStore) SelectERC1155Transfers(ctx context.Context, filter TransferFilter, page Page) (txs []customTypes.ERC1155TxStruct, next string, err error) {
+	after, first, err := page.after("erc1155-transfer")
+	if err != nil {
+		return nil, "", err
+	}
+	q := filter.query(erc1155TxColumns(s), "ERC1155Tx")
+	if !first {
+		q.sql += ` AND (block_number, log_index, batch_index) < (` + q.arg(after.Block) + `, ` + q.arg(after.LogIndex) + `, ` + q.arg(after.BatchIndex) + `)`
+	}
+	q.sql += ` ORDER BY block_number DESC, log_index DESC, batch_index DESC`
+	q.limit(page)
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	txs = []customTypes.ERC1155TxStruct{}
+	for rows.Next() {
+		var tx customTypes.ERC1155TxStruct
+		var price, priceSource, confidence sql.NullString
+		err = rows.Scan(&tx.ChainId, &tx.Timestamp, &tx.BlockNumber, &tx.TxHash, &tx.LogIndex, &tx.BatchIndex, &tx.Tag, (*addressColumn)(&tx.Operator),
+			(*addressColumn)(&tx.FromAddr), (*addressColumn)(&tx.ToAddr), &tx.TokenId, &tx.Amount, (*addressColumn)(&tx.Collection), &price, (*addressColumn)(&tx.Currency), &priceSource, &confidence)
+		if err != nil {
+			return nil, "", err
+		}
+		tx.Price = price.String
+		tx.PriceSource = priceSource.String
+		tx.Confidence = confidence.String
+		txs = append(txs, tx)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+	txs, next = pageRows(txs, page.Limit, func(tx customTypes.ERC1155TxStruct) cursor {
+		return cursor{Kind: "erc1155-transfer", Block: tx.BlockNumber, LogIndex: tx.LogIndex, BatchIndex: tx.BatchIndex}
+	})
+	return txs, next, nil
+}
+
+// Get a page of the tokens of an ERC-1155 collection, the last minted first
+func (s *sqlStore) SelectERC1155Tokens(ctx context.Context, chainId uint64, collection string, page Page) (tokens []customTypes.ERC1155SupplyStruct, next string, err error) {
+	after, first, err := page.after("erc1155-token")
+	if err != nil {
+		return nil, "", err
+	}
+	q := &listQuery{}
+	q.sql = `SELECT ` + erc1155TokenColumns(s) + ` FROM ERC1155Token t WHERE t.chain_id = ` + q.arg(chainId) + ` AND t.collection = ` + q.arg(strings.ToLower(collection))
+	if !first {
+		q.sql += ` AND (t.mint_block_number, ` + s.uintKey("t.token_id", "") + `) < (` + q.arg(after.Block) + `, ` + s.uintKey(q.arg(after.TokenId), "") + `)`
+	}
+	q.sql += ` ORDER BY t.mint_block_number DESC, ` + s.uintKey("t.token_id", " DESC")
+	q.limit(page)
+	tokens, err = s.selectERC1155Tokens(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	tokens, next = pageRows(tokens, page.Limit, func(token customTypes.ERC1155SupplyStruct) cursor {
+		return cursor{Kind: "erc1155-token", Block: token.MintBlockNumber, TokenId: token.TokenId}
+	})
+	return tokens, next, nil
+}
+
+func (s *sqlStore) SelectERC1155Token(ctx context.Context, chainId uint64, collection string, tokenId string) (token customTypes.ERC1155SupplyStruct, found bool, err error) {
+	tokens, err := s.selectERC1155Tokens(ctx, `SELECT `+erc1155TokenColumns(s)+` FROM ERC1155Token t WHERE t.chain_id = $1 AND t.collection = $2 AND t.token_id = $3`,
+		chainId, strings.ToLower(collection), tokenId)
+	if err != nil || len(tokens) == 0 {
+		return token, false, err
+	}
+	return tokens[0], true, nil
+}
+
+func (s *sqlStore) selectERC1155Tokens(ctx context.Context, query string, args ...any) (tokens []customTypes.ERC1155SupplyStruct, err error) {
+	rows, err := s.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens = []customTypes.ERC1155SupplyStruct{}
+	for rows.Next() {
+		var token customTypes.ERC1155SupplyStruct
+		err = rows.Scan(&token.ChainId, &token.MintTimestamp, &token.MintBlockNumber, &token.MintTxHash, &token.URI, &token.TokenId, (*addressColumn)(&token.Collection), &token.Supply)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// Get a page of the ERC-1155 balances of a holder by collection and token
+func (s *sqlStore) SelectERC1155Balances(ctx context.Context, chainId uint64, holder string, page Page) (balances []customTypes.ERC1155BalanceStruct, next string, err error) {
+	after, first, err := page.after("erc1155-balance")
+	if err != nil {
+		return nil, "", err
+	}
+	q := &listQuery{}
+	q.sql = `SELECT b.chain_id, b.token_id, b.collection, b.holder, b.balance, ` + erc1155UriColumn + `
+	FROM ERC1155Balance b LEFT JOIN ERC1155Token t ON t.chain_id = b.chain_id AND t.collection = b.collection AND t.token_id = b.token_id
+	WHERE b.chain_id = ` + q.arg(chainId) + ` AND b.holder = ` + q.arg(strings.ToLower(holder))
+	if !first {
+		q.sql += ` AND (b.collection, ` + s.uintKey("b.token_id", "") + `) > (` + q.arg(after.Collection) + `, ` + s.uintKey(q.arg(after.TokenId), "") + `)`
+	}
+	q.sql += ` ORDER BY b.collection, ` + s.uintKey("b.token_id", "")
+	q.limit(page)
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	balances = []customTypes.ERC1155BalanceStruct{}
+	for rows.Next() {
+		var balance customTypes.ERC1155BalanceStruct
+		err = rows.Scan(&balance.ChainId, &balance.TokenId, (*addressColumn)(&balance.Collection), (*addressColumn)(&balance.Holder), &balance.Balance, &balance.URI)
+		if err != nil {
+			return nil, "", err
+		}
+		balances = append(balances, balance)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+	balances, next = pageRows(balances, page.Limit, func(balance customTypes.ERC1155BalanceStruct) cursor {
+		return cursor{Kind: "erc1155-balance", Collection: strings.ToLower(balance.Collection.Hex()), TokenId: balance.TokenId}
+	})
+	return balances, next, nil
+}
+
+// Count the holders and tokens of an ERC-1155 collection and its transactions since a unix timestamp
+func (s *sqlStore) SelectERC1155CollectionStats(ctx context.Context, chainId uint64, collection string, since int64) (stats customTypes.ERC1155CollectionStatsStruct, err error) {
+	collection = strings.ToLower(collection)
+	err = s.queryRow(ctx, `SELECT COUNT(DISTINCT holder) FROM ERC1155Balance WHERE chain_id = $1 AND collection = $2`, chainId, collection).Scan(&stats.OwnerCount)
+	if err != nil {
+		return stats, err
+	}
+	err = s.queryRow(ctx, `SELECT COUNT(*) FROM ERC1155Token WHERE chain_id = $1 AND collection = $2`, chainId, collection).Scan(&stats.TokenCount)
+	if err != nil {
+		return stats, err
+	}
+	err = s.queryRow(ctx, `SELECT COUNT(DISTINCT hash) FROM ERC1155Tx WHERE chain_id = $1 AND collection = $2 AND timestamp > `+s.time("$3"), chainId, collection, since).Scan(&stats.TxCount)
+	return stats, err
+}
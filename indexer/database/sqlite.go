@@ -0,0 +1,217 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLite driver with the functions used by the queries
+const sqliteDriver = "sqlite3_segment"
+
+func init() {
+	sql.Register(sqliteDriver, strictDriver{&sqlite3.SQLiteDriver{ConnectHook: sqliteFunctions}})
+}
+
+// SQLite binds NULL to the missing arguments and ignores the extra ones where Postgres rejects the statement.
+// They are rejected here too so that the statements tested on SQLite run on Postgres
+type strictDriver struct {
+	*sqlite3.SQLiteDriver
+}
+
+func (d strictDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return strictConn{conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+type strictConn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c strictConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	err := checkArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.ExecContext(ctx, query, args)
+}
+
+func (c strictConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	err := checkArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.QueryContext(ctx, query, args)
+}
+
+var boundPlaceholder = regexp.MustCompile(`\?([0-9]+)`)
+
+// A statement takes as many arguments as its highest ?n, like a Postgres one with $n
+func checkArgs(query string, args []driver.NamedValue) error {
+	required := 0
+	for _, match := range boundPlaceholder.FindAllStringSubmatch(query, -1) {
+		n, _ := strconv.Atoi(match[1])
+		if n > required {
+			required = n
+		}
+	}
+	if len(args) != required {
+		return fmt.Errorf("got %d parameters but the statement requires %d", len(args), required)
+	}
+	return nil
+}
+
+// Connection settings added to the database path: writers wait for each other instead of failing
+// and a write transaction takes its lock up front so that it cannot deadlock with another one
+func sqliteDSN(path string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "_journal_mode=WAL&_busy_timeout=10000&_txlock=immediate"
+}
+
+// Give SQLite the numeric arithmetic of Postgres on the uint256 values stored as decimal text
+func sqliteFunctions(conn *sqlite3.SQLiteConn) error {
+	functions := map[string]interface{}{
+		"numeric_add": func(a interface{}, b interface{}) (interface{}, error) {
+			return numericOp(func(x *big.Int, y *big.Int) *big.Int { return x.Add(x, y) }, a, b)
+		},
+		"numeric_neg": func(a interface{}) (interface{}, error) {
+			return numericOp(func(x *big.Int, _ *big.Int) *big.Int { return x.Neg(x) }, a, int64(0))
+		},
+		"numeric_div": func(a interface{}, b interface{}) (interface{}, error) {
+			return numericOp(func(x *big.Int, y *big.Int) *big.Int {
+				if y.Sign() == 0 {
+					return nil
+				}
+				return x.Quo(x, y)
+			}, a, b)
+		},
+		// Like LEAST, NULL only when both are
+		"numeric_least": func(a interface{}, b interface{}) (interface{}, error) {
+			switch {
+			case isNull(a) && isNull(b):
+				return nil, nil
+			case isNull(a):
+				return b, nil
+			case isNull(b):
+				return a, nil
+			}
+			return numericOp(func(x *big.Int, y *big.Int) *big.Int {
+				if y.Cmp(x) < 0 {
+					return y
+				}
+				return x
+			}, a, b)
+		},
+		"ln": math.Log,
+	}
+	for name, function := range functions {
+		err := conn.RegisterFunc(name, function, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := conn.RegisterAggregator("numeric_sum", func() *numericAggregate {
+		return &numericAggregate{keep: func(total *big.Int, value *big.Int) *big.Int { return total.Add(total, value) }}
+	}, true)
+	if err != nil {
+		return err
+	}
+	return conn.RegisterAggregator("numeric_min", func() *numericAggregate {
+		return &numericAggregate{keep: func(low *big.Int, value *big.Int) *big.Int {
+			if value.Cmp(low) < 0 {
+				return value
+			}
+			return low
+		}}
+	}, true)
+}
+
+var errDivisionByZero = errors.New("division by zero")
+
+// Apply op to two values, NULL if one of them is
+func numericOp(op func(x *big.Int, y *big.Int) *big.Int, a interface{}, b interface{}) (interface{}, error) {
+	if isNull(a) || isNull(b) {
+		return nil, nil
+	}
+	x, err := toBig(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toBig(b)
+	if err != nil {
+		return nil, err
+	}
+	result := op(x, y)
+	if result == nil {
+		return nil, errDivisionByZero
+	}
+	return result.String(), nil
+}
+
+// The driver gives NULL to the functions as a nil []byte
+func isNull(value interface{}) bool {
+	bytes, isBytes := value.([]byte)
+	return value == nil || (isBytes && bytes == nil)
+}
+
+func toBig(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case int64:
+		return big.NewInt(v), nil
+	case string:
+		if parsed, ok := new(big.Int).SetString(v, 10); ok {
+			return parsed, nil
+		}
+	case []byte:
+		if parsed, ok := new(big.Int).SetString(string(v), 10); ok {
+			return parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid numeric value %v", value)
+}
+
+// SUM or MIN of the non NULL values, NULL without any
+type numericAggregate struct {
+	keep   func(current *big.Int, value *big.Int) *big.Int
+	result *big.Int
+	err    error
+}
+
+func (a *numericAggregate) Step(value interface{}) {
+	if isNull(value) || a.err != nil {
+		return
+	}
+	parsed, err := toBig(value)
+	if err != nil {
+		a.err = err
+		return
+	}
+	if a.result == nil {
+		a.result = parsed
+		return
+	}
+	a.result = a.keep(a.result, parsed)
+}
+
+func (a *numericAggregate) Done() (interface{}, error) {
+	if a.err != nil || a.result == nil {
+		return nil, a.err
+	}
+	return a.result.String(), nil
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"strings"
+
+	"workspace/customTypes"
+)
+
+// Buckets of the collection stats, the unix timestamp of their start is a multiple of size
+var statsPeriods = []struct {
+	name string
+	size uint64
+}{{"hour", 3600}, {"day", 86400}}
+
+// Currency of the sales paid in the native currency
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// Sales and the transfers priced from their payments, the transfers whose payment could not be attributed to the buyer are left out
+func statsTrades(d dialect) string {
+	return `SELECT chain_id, collection, ` + d.epoch("timestamp") + ` AS ts, buyer, seller, currency, price, amount FROM Sale
+	UNION ALL
+	SELECT chain_id, collection, ` + d.epoch("timestamp") + `, to_addr, from_addr, currency, price, 1 FROM ERC721Tx
+	WHERE tag = 'transfer' AND price_source IN ('native', 'erc20') AND confidence IN ('high', 'medium')`
+}
+
+func isStatsTrade(transfer customTypes.ERC721TxStruct) bool {
+	return transfer.Tag == "transfer" && (transfer.PriceSource == "native" || transfer.PriceSource == "erc20") &&
+		(transfer.Confidence == "high" || transfer.Confidence == "medium")
+}
+
+type statsKey struct {
+	collection string
+	period     string
+	bucket     uint64
+}
+
+type statsDelta struct {
+	transfers    int
+	mints        int
+	burns        int
+	sales        int
+	nativeTokens *big.Int
+	volume       *big.Int
+	floor        *big.Int
+}
+
+type statsParticipant struct {
+	key     statsKey
+	role    string
+	address string
+}
+
+// What the rows written for a block add to the collection stats
+type blockStats struct {
+	chainId      uint64
+	keys         []statsKey
+	deltas       map[statsKey]*statsDelta
+	participants []statsParticipant
+	// Collections whose owners changed, with the time of the change
+	holders map[string]uint64
+}
+
+func newBlockStats(chainId uint64) *blockStats {
+	return &blockStats{chainId: chainId, deltas: map[statsKey]*statsDelta{}, holders: map[string]uint64{}}
+}
+
+func (s *blockStats) buckets(collection string, timestamp uint64, add func(key statsKey, delta *statsDelta)) {
+	for _, period := range statsPeriods {
+		key := statsKey{collection, period.name, timestamp - timestamp%period.size}
+		delta, ok := s.deltas[key]
+		if !ok {
+			delta = &statsDelta{nativeTokens: new(big.Int), volume: new(big.Int)}
+			s.deltas[key] = delta
+			s.keys = append(s.keys, key)
+		}
+		add(key, delta)
+	}
+}
+
+func (s *blockStats) addTransfer(transfer customTypes.ERC721TxStruct) {
+	collection := strings.ToLower(transfer.Collection.Hex())
+	s.holders[collection] = transfer.Timestamp
+	s.buckets(collection, transfer.Timestamp, func(key statsKey, delta *statsDelta) {
+		switch transfer.Tag {
+		case "mint":
+			delta.mints++
+		case "burn":
+			delta.burns++
+		default:
+			delta.transfers++
+		}
+	})
+	if isStatsTrade(transfer) {
+		s.addTrade(collection, transfer.Timestamp, transfer.ToAddr.Hex(), transfer.FromAddr.Hex(), transfer.Currency.Hex(), transfer.Price, "1")
+	}
+}
+
+func (s *blockStats) addSale(sale customTypes.SaleStruct) {
+	s.addTrade(strings.ToLower(sale.Collection.Hex()), sale.Timestamp, sale.Buyer.Hex(), sale.Seller.Hex(), sale.Currency.Hex(), sale.Price, sale.Amount)
+}
+
+func (s *blockStats) addTrade(collection string, timestamp uint64, buyer string, seller string, currency string, price string, amount string) {
+	value, ok := new(big.Int).SetString(price, 10)
+	tokens, ok2 := new(big.Int).SetString(amount, 10)
+	native := ok && ok2 && tokens.Sign() > 0 && strings.ToLower(currency) == zeroAddress
+
+	s.buckets(collection, timestamp, func(key statsKey, delta *statsDelta) {
+		delta.sales++
+		s.participants = append(s.participants,
+			statsParticipant{key, "buyer", strings.ToLower(buyer)},
+			statsParticipant{key, "seller", strings.ToLower(seller)})
+		if !native {
+			return
+		}
+		delta.volume.Add(delta.volume, value)
+		delta.nativeTokens.Add(delta.nativeTokens, tokens)
+		unit := new(big.Int).Quo(value, tokens)
+		if delta.floor == nil || unit.Cmp(delta.floor) < 0 {
+			delta.floor = unit
+		}
+	})
+}
+
+// Add the block to the stats of its buckets and take a snapshot of the holders of the collections it changed
+func (s *blockStats) write(w writer) (err error) {
+	upsertStats := `INSERT INTO CollectionStats(chain_id, collection, period, bucket, transfers, mints, burns, sales, native_tokens, volume, floor)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, ` + w.numeric("$9") + `, ` + w.numeric("$10") + `, ` + w.numeric("$11") + `)
+	ON CONFLICT (chain_id, collection, period, bucket) DO UPDATE SET transfers = CollectionStats.transfers + $5, mints = CollectionStats.mints + $6,
+	burns = CollectionStats.burns + $7, sales = CollectionStats.sales + $8, native_tokens = ` + w.add("CollectionStats.native_tokens", "EXCLUDED.native_tokens") + `,
+	volume = ` + w.add("CollectionStats.volume", "EXCLUDED.volume") + `, floor = ` + w.least("CollectionStats.floor", "EXCLUDED.floor")
+	for _, key := range s.keys {
+		delta := s.deltas[key]
+		var floor interface{}
+		if delta.floor != nil {
+			floor = delta.floor.String()
+		}
+		_, err = w.exec(upsertStats, s.chainId, key.collection, key.period, key.bucket, delta.transfers, delta.mints, delta.burns, delta.sales,
+			delta.nativeTokens.String(), delta.volume.String(), floor)
+		if err != nil {
+			return err
+		}
+	}
+
+	// An address is only counted the first time it trades in a bucket
+	for _, participant := range s.participants {
+		result, err := w.exec(`INSERT INTO CollectionStatsParticipant(chain_id, collection, period, bucket, role, address) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING`,
+			s.chainId, participant.key.collection, participant.key.period, participant.key.bucket, participant.role, participant.address)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			continue
+		}
+		column := "buyers"
+		if participant.role == "seller" {
+			column = "sellers"
+		}
+		_, err = w.exec(`UPDATE CollectionStats SET `+column+` = `+column+` + 1 WHERE chain_id = $1 AND collection = $2 AND period = $3 AND bucket = $4`,
+			s.chainId, participant.key.collection, participant.key.period, participant.key.bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	for collection, timestamp := range s.holders {
+		_, err = w.exec(`UPDATE CollectionStats SET holders = (SELECT COUNT(DISTINCT owner) FROM ERC721 WHERE chain_id = $1 AND collection = $2 AND owner <> $5)
+		WHERE chain_id = $1 AND collection = $2 AND ((period = 'hour' AND bucket = $3) OR (period = 'day' AND bucket = $4))`,
+			s.chainId, collection, timestamp-timestamp%3600, timestamp-timestamp%86400, zeroAddress)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recompute the stats of the buckets from the one holding from with the stored rows
+func (w writer) rebuildStats(chainId uint64, from uint64) (err error) {
+	trades := statsTrades(w.dialect)
+	for _, period := range statsPeriods {
+		start := from - from%period.size
+		queries := []struct {
+			query string
+			args  []interface{}
+		}{
+			{`DELETE FROM CollectionStats WHERE chain_id = $1 AND period = $2 AND bucket >= $3`, []interface{}{chainId, period.name, start}},
+			{`DELETE FROM CollectionStatsParticipant WHERE chain_id = $1 AND period = $2 AND bucket >= $3`, []interface{}{chainId, period.name, start}},
+			{`INSERT INTO CollectionStats(chain_id, collection, period, bucket, transfers, mints, burns)
+			SELECT chain_id, collection, CAST($2 AS text), ` + w.epoch("timestamp") + ` / CAST($3 AS bigint) * CAST($3 AS bigint),
+			COUNT(*) FILTER (WHERE tag = 'transfer'), COUNT(*) FILTER (WHERE tag = 'mint'), COUNT(*) FILTER (WHERE tag = 'burn')
+			FROM ERC721Tx WHERE chain_id = $1 AND timestamp >= ` + w.time("$4") + ` GROUP BY 1, 2, 3, 4`, []interface{}{chainId, period.name, period.size, start}},
+			{`INSERT INTO CollectionStats(chain_id, collection, period, bucket, sales, native_tokens, volume, floor)
+			SELECT chain_id, collection, CAST($2 AS text), ts - ts % CAST($3 AS bigint), COUNT(*), COALESCE(` + w.sum("amount") + ` FILTER (WHERE currency = $5), 0),
+			COALESCE(` + w.sum("price") + ` FILTER (WHERE currency = $5), 0), ` + w.min(w.div("price", "amount")) + ` FILTER (WHERE currency = $5)
+			FROM (` + trades + `) t WHERE chain_id = $1 AND ts >= $4 GROUP BY 1, 2, 3, 4
+			ON CONFLICT (chain_id, collection, period, bucket) DO UPDATE SET sales = EXCLUDED.sales, native_tokens = EXCLUDED.native_tokens,
+			volume = EXCLUDED.volume, floor = EXCLUDED.floor`, []interface{}{chainId, period.name, period.size, start, zeroAddress}},
+			{`INSERT INTO CollectionStatsParticipant(chain_id, collection, period, bucket, role, address)
+			SELECT chain_id, collection, CAST($2 AS text), ts - ts % CAST($3 AS bigint), 'buyer', buyer FROM (` + trades + `) t WHERE chain_id = $1 AND ts >= $4
+			UNION
+			SELECT chain_id, collection, CAST($2 AS text), ts - ts % CAST($3 AS bigint), 'seller', seller FROM (` + trades + `) t WHERE chain_id = $1 AND ts >= $4
+			ON CONFLICT DO NOTHING`, []interface{}{chainId, period.name, period.size, start}},
+			{`UPDATE CollectionStats AS s SET buyers = p.buyers, sellers = p.sellers FROM (
+				SELECT collection, bucket, COUNT(*) FILTER (WHERE role = 'buyer') AS buyers, COUNT(*) FILTER (WHERE role = 'seller') AS sellers
+				FROM CollectionStatsParticipant WHERE chain_id = $1 AND period = $2 AND bucket >= $3 GROUP BY 1, 2
+			) p WHERE s.chain_id = $1 AND s.period = $2 AND s.collection = p.collection AND s.bucket = p.bucket`, []interface{}{chainId, period.name, start}},
+			// The owners are only known now, the holders of the past buckets stay unknown
+			{`UPDATE CollectionStats AS s SET holders = (SELECT COUNT(DISTINCT owner) FROM ERC721 n WHERE n.chain_id = s.chain_id AND n.collection = s.collection AND n.owner <> $4)
+			WHERE s.chain_id = $1 AND s.period = $2 AND s.bucket >= $3 AND s.transfers + s.mints + s.burns > 0
+			AND s.bucket = (SELECT MAX(bucket) FROM CollectionStats l WHERE l.chain_id = s.chain_id AND l.collection = s.collection AND l.period = s.period)`,
+				[]interface{}{chainId, period.name, start, zeroAddress}},
+		}
+		for _, q := range queries {
+			_, err = w.exec(q.query, q.args...)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Rebuild the collection stats of every indexed chain from the stored rows
+func (s *sqlStore) RebuildStats(ctx context.Context) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		rows, err := w.query(`SELECT chain_id FROM State`)
+		if err != nil {
+			return err
+		}
+		var chainIds []uint64
+		for rows.Next() {
+			var chainId uint64
+			err = rows.Scan(&chainId)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			chainIds = append(chainIds, chainId)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		for _, chainId := range chainIds {
+			err = w.rebuildStats(chainId, 0)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// First timestamp of the rows written after ancestor, whose buckets must be rebuilt by a rollback
+func (w writer) rollbackStatsFrom(chainId uint64, ancestor uint64) (from sql.NullInt64, err error) {
+	err = w.queryRow(`SELECT `+w.epoch("MIN(ts)")+` FROM (
+		SELECT MIN(timestamp) AS ts FROM ERC721Tx WHERE chain_id = $1 AND block_number > $2
+		UNION ALL
+		SELECT MIN(timestamp) FROM Sale WHERE chain_id = $1 AND block_number > $2
+	) t`, chainId, ancestor).Scan(&from)
+	return from, err
+}
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"workspace/customTypes"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/lib/pq"
+)
+
+// Every operation of the indexer and the API on their database
+type Store interface {
+	// Indexing
+	ApplyBlock(ctx context.Context, data customTypes.BlockData) error
+	ApplyRange(ctx context.Context, blocks []customTypes.BlockData, last customTypes.BlockStruct) error
+	Rollback(ctx context.Context, chainId uint64, ancestor uint64) error
+
+	// Sync state
+	SelectBlock(ctx context.Context, chainId uint64, startBlock uint64) (uint64, error)
+	SelectBlockAtOrBelow(ctx context.Context, chainId uint64, number uint64) (stored uint64, hash string, found bool, err error)
+	SelectBlockHash(ctx context.Context, chainId uint64, number uint64) (hash string, found bool, err error)
+	SelectLastBlock(ctx context.Context, chainId uint64) (number uint64, found bool, err error)
+	SelectCollectionExists(ctx context.Context, chainId uint64, address common.Address) (bool, error)
+	SelectERC1155CollectionExists(ctx context.Context, chainId uint64, address common.Address) (bool, error)
+
+	// Metadata and rarity
+	SelectPendingMetadata(ctx context.Context, limit int) ([]customTypes.MetadataTask, error)
+	InsertMetadata(ctx context.Context, task customTypes.MetadataTask, metadata customTypes.MetadataStruct) error
+	UpdateMetadataFailure(ctx context.Context, task customTypes.MetadataTask, status string, nextAttempt time.Time, reason string) error
+	SelectStaleRarity(ctx context.Context) ([]StaleCollection, error)
+	UpdateRarity(ctx context.Context, chainId uint64, collection string) error
+
+	// Reads of the API, addresses can have any case. The lists return the cursor of their next page, empty after the last one
+	SelectTransfers(ctx context.Context, filter TransferFilter, page Page) (txs []customTypes.ERC721TxStruct, next string, err error)
+	SelectNFTs(ctx context.Context, filter NFTFilter, page Page) (nfts []customTypes.NFTStruct, next string, err error)
+	SelectNFT(ctx context.Context, chainId uint64, collection string, tokenId string) (nft customTypes.NFTStruct, found bool, err error)
+	SelectNFTsByKey(ctx context.Context, chainId uint64, keys []TokenKey) ([]customTypes.NFTStruct, error)
+	SelectCollections(ctx context.Context, chainId uint64, addresses []string) ([]customTypes.ERC721CollectionStruct, error)
+	SelectTraitCounts(ctx context.Context, chainId uint64, collection string) ([]customTypes.TraitCountStruct, error)
+	RefreshMetadata(ctx context.Context, chainId uint64, collection string, tokenId string) (found bool, err error)
+	SelectCollectionWindow(ctx context.Context, chainId uint64, collection string, period string, since int64) (customTypes.CollectionWindowStruct, error)
+	SelectStatsSeries(ctx context.Context, chainId uint64, collection string, period string, size int64, from int64, to int64) ([]customTypes.StatsPointStruct, error)
+	SelectERC1155Transfers(ctx context.Context, filter TransferFilter, page Page) (txs []customTypes.ERC1155TxStruct, next string, err error)
+	SelectERC1155Tokens(ctx context.Context, chainId uint64, collection string, page Page) (tokens []customTypes.ERC1155SupplyStruct, next string, err error)
+	SelectERC1155Token(ctx context.Context, chainId uint64, collection string, tokenId string) (token customTypes.ERC1155SupplyStruct, found bool, err error)
+	SelectERC1155Balances(ctx context.Context, chainId uint64, holder string, page Page) (balances []customTypes.ERC1155BalanceStruct, next string, err error)
+	SelectERC1155CollectionStats(ctx context.Context, chainId uint64, collection string, since int64) (customTypes.ERC1155CollectionStatsStruct, error)
+	SelectTransferStream(ctx context.Context, filter TransferFilter, from string, limit int) (events []StreamEvent, next string, err error)
+
+	// Webhooks
+	InsertWebhook(ctx context.Context, webhook customTypes.WebhookStruct) (customTypes.WebhookStruct, error)
+	SelectWebhooks(ctx context.Context, page Page) (webhooks []customTypes.WebhookStruct, next string, err error)
+	SelectWebhook(ctx context.Context, id int64) (webhook customTypes.WebhookStruct, found bool, err error)
+	SelectActiveWebhooks(ctx context.Context) ([]customTypes.WebhookStruct, error)
+	DisableWebhook(ctx context.Context, id int64) (found bool, err error)
+	QueueWebhookDeliveries(ctx context.Context, webhook customTypes.WebhookStruct, next string, deliveries []customTypes.WebhookDeliveryStruct) (queued bool, err error)
+	InsertWebhookDelivery(ctx context.Context, webhookId int64, event string, payload []byte) (task customTypes.WebhookTask, found bool, err error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]customTypes.WebhookTask, error)
+	InsertWebhookAttempt(ctx context.Context, task customTypes.WebhookTask, attempt customTypes.WebhookAttemptStruct, status string, nextAttempt time.Time) error
+	SelectWebhookDeliveries(ctx context.Context, webhookId int64, page Page) (deliveries []customTypes.WebhookDeliveryStruct, next string, err error)
+
+	// Maintenance
+	MigrateUp(ctx context.Context) (applied int, err error)
+	MigrateDown(ctx context.Context, steps int) (reverted int, err error)
+	SelectMigrations(ctx context.Context) ([]MigrationStatus, error)
+	ResetDatabase(ctx context.Context) error
+	RebuildStats(ctx context.Context) error
+
+	// Check that the database can be reached
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Open the db without touching the schema. uri is a connection string for postgres and a file path for sqlite
+func OpenDatabase(driver string, uri string, maxConnections int) (Store, error) {
+	var d dialect
+	switch driver {
+	case "postgres":
+		d = postgresDialect{}
+	case "sqlite":
+		d = sqliteDialect{}
+		uri = sqliteDSN(uri)
+	default:
+		return nil, fmt.Errorf("unknown database driver %q, expected postgres or sqlite", driver)
+	}
+
+	db, err := sql.Open(d.driver(), uri)
+	if err != nil {
+		return nil, err
+	}
+	// The connections are kept open between the queries, up to the limit
+	db.SetMaxOpenConns(maxConnections)
+	if maxConnections > 0 {
+		db.SetMaxIdleConns(maxConnections)
+	}
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqlStore{db: db, dialect: d}, nil
+}
+
+// Start db and apply the pending migrations
+func StartDatabase(driver string, uri string, maxConnections int) (Store, error) {
+	store, err := OpenDatabase(driver, uri, maxConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := store.MigrateUp(context.Background())
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	log.Println("Applied", applied, "pending migrations")
+
+	return store, nil
+}
+
+// Store over database/sql, the dialect gives the SQL of its backend
+type sqlStore struct {
+	db *sql.DB
+	dialect
+}
+
+func (s *sqlStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqlStore) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.db.ExecContext(ctx, s.rebind(query), args...)
+}
+
+func (s *sqlStore) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, s.rebind(query), args...)
+}
+
+func (s *sqlStore) queryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return s.db.QueryRowContext(ctx, s.rebind(query), args...)
+}
+
+// Run write in a transaction, committed when it returns nil
+func (s *sqlStore) inTx(ctx context.Context, write func(w writer) error) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = write(writer{ctx: ctx, tx: tx, dialect: s.dialect})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Statements of a transaction
+type writer struct {
+	ctx context.Context
+	tx  *sql.Tx
+	dialect
+}
+
+func (w writer) exec(query string, args ...any) (sql.Result, error) {
+	return w.tx.ExecContext(w.ctx, w.rebind(query), args...)
+}
+
+func (w writer) query(query string, args ...any) (*sql.Rows, error) {
+	return w.tx.QueryContext(w.ctx, w.rebind(query), args...)
+}
+
+func (w writer) queryRow(query string, args ...any) *sql.Row {
+	return w.tx.QueryRowContext(w.ctx, w.rebind(query), args...)
+}
@@ -0,0 +1,453 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"workspace/customTypes"
+	"workspace/database/dbtest"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Every backend runs the same suite on an empty schema. Postgres is tested when SEGMENT_TEST_POSTGRES
+// is the connection string of a database the tests may wipe, it must be set in CI
+func TestStore(t *testing.T) {
+	backends := map[string]func(t *testing.T) Store{
+		"sqlite": func(t *testing.T) Store {
+			return openTestStore(t, "sqlite", filepath.Join(t.TempDir(), "segment.db"))
+		},
+		"postgres": func(t *testing.T) Store {
+			uri := os.Getenv("SEGMENT_TEST_POSTGRES")
+			if uri == "" && os.Getenv("CI") != "" {
+				t.Fatal("SEGMENT_TEST_POSTGRES is not set, the Postgres backend must be tested in CI")
+			}
+			if uri == "" {
+				t.Skip("SEGMENT_TEST_POSTGRES is not set, see the readme to test the Postgres backend")
+			}
+			store := openTestStore(t, "postgres", uri)
+			err := store.ResetDatabase(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return store
+		},
+	}
+	tests := map[string]func(t *testing.T, store Store){
+		"checkpoint":         testCheckpoint,
+		"transfers":          testTransfers,
+		"nft order":          testNFTOrder,
+		"erc1155":            testERC1155,
+		"rollback":           testRollback,
+		"erc1155 rollback":   testERC1155Rollback,
+		"trait rollback":     testTraitRollback,
+		"metadata":           testMetadata,
+		"invalid cursor":     testInvalidCursor,
+		"replayed block":     testReplayedBlock,
+		"collection exists":  testCollectionExists,
+		"batched nft lookup": testNFTsByKey,
+	}
+	for backend, open := range backends {
+		t.Run(backend, func(t *testing.T) {
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					test(t, open(t))
+				})
+			}
+		})
+	}
+}
+
+func openTestStore(t *testing.T, driver string, uri string) Store {
+	store, err := StartDatabase(driver, uri, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func apply(t *testing.T, store Store, blocks ...customTypes.BlockData) {
+	t.Helper()
+	for _, data := range blocks {
+		err := store.ApplyBlock(context.Background(), data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func startAt(t *testing.T, store Store, number uint64) {
+	t.Helper()
+	_, err := store.SelectBlock(context.Background(), dbtest.Chain, number)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkpoint(t *testing.T, store Store) uint64 {
+	t.Helper()
+	number, err := store.SelectBlock(context.Background(), dbtest.Chain, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return number
+}
+
+func testCheckpoint(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+
+	// A block committed ahead of the checkpoint does not move it
+	apply(t, store, dbtest.MintBlock(101))
+	if number := checkpoint(t, store); number != 100 {
+		t.Fatalf("checkpoint %d after block 101, expected 100", number)
+	}
+	apply(t, store, dbtest.MintBlock(100))
+	if number := checkpoint(t, store); number != 101 {
+		t.Fatalf("checkpoint %d after blocks 100 and 101, expected 101", number)
+	}
+
+	err := store.ApplyRange(ctx, []customTypes.BlockData{dbtest.MintBlock(103, "1")}, dbtest.Block(110))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number := checkpoint(t, store); number != 110 {
+		t.Errorf("checkpoint %d after the range, expected 110", number)
+	}
+	last, found, err := store.SelectLastBlock(ctx, dbtest.Chain)
+	if err != nil || !found || last != 110 {
+		t.Errorf("last block %d found %v: %v", last, found, err)
+	}
+	hash, found, err := store.SelectBlockHash(ctx, dbtest.Chain, 103)
+	if err != nil || !found || hash != dbtest.Block(103).Hash {
+		t.Errorf("hash of block 103 %q found %v: %v", hash, found, err)
+	}
+	stored, _, found, err := store.SelectBlockAtOrBelow(ctx, dbtest.Chain, 109)
+	if err != nil || !found || stored != 103 {
+		t.Errorf("block at or below 109 is %d found %v: %v", stored, found, err)
+	}
+}
+
+func testTransfers(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	sale := dbtest.MintBlock(101)
+	sale.Txs = append(sale.Txs, dbtest.Transfer(101, 0, "transfer", dbtest.Alice, dbtest.Bob, "1"))
+	sale.Txs[0].Price, sale.Txs[0].PriceSource, sale.Txs[0].Confidence = "1000000000000000000", "native", "high"
+	apply(t, store, dbtest.MintBlock(100, "1", "2"), sale)
+
+	txs, next, err := store.SelectTransfers(ctx, TransferFilter{ChainId: dbtest.Chain, Collection: dbtest.Collection.Hex()}, Page{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || next == "" {
+		t.Fatalf("%d transfers and cursor %q, expected a full first page", len(txs), next)
+	}
+	// The last block comes first
+	if txs[0].Tag != "transfer" || txs[0].Price != "1000000000000000000" || txs[0].Currency != (common.Address{}) || txs[0].Confidence != "high" {
+		t.Errorf("first transfer %+v", txs[0])
+	}
+	if txs[1].Price != "" || txs[1].PriceSource != "" {
+		t.Errorf("unpriced transfer %+v", txs[1])
+	}
+	rest, next, err := store.SelectTransfers(ctx, TransferFilter{ChainId: dbtest.Chain, Collection: dbtest.Collection.Hex()}, Page{Limit: 2, Cursor: next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 1 || next != "" || rest[0].TokenId != "1" || rest[0].LogIndex != 0 {
+		t.Errorf("last page %+v with cursor %q", rest, next)
+	}
+
+	byBob, _, err := store.SelectTransfers(ctx, TransferFilter{ChainId: dbtest.Chain, Address: dbtest.Bob.Hex()}, Page{Limit: 10})
+	if err != nil || len(byBob) != 1 {
+		t.Errorf("%d transfers of bob: %v", len(byBob), err)
+	}
+	nft, found, err := store.SelectNFT(ctx, dbtest.Chain, dbtest.Collection.Hex(), "1")
+	if err != nil || !found || nft.Owner != dbtest.Bob {
+		t.Errorf("owner of token 1 is %s found %v: %v", nft.Owner, found, err)
+	}
+}
+
+func testNFTOrder(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	apply(t, store, dbtest.MintBlock(100, "9", "10", "2", "100"), dbtest.MintBlock(101, "1"))
+
+	var tokenIds []string
+	page := Page{Limit: 2}
+	for {
+		nfts, next, err := store.SelectNFTs(ctx, NFTFilter{ChainId: dbtest.Chain, Collection: dbtest.Collection.Hex()}, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, nft := range nfts {
+			tokenIds = append(tokenIds, nft.TokenId)
+		}
+		if next == "" {
+			break
+		}
+		page.Cursor = next
+	}
+	// Last minted first, then the token ids by value
+	if fmt.Sprint(tokenIds) != "[1 100 10 9 2]" {
+		t.Errorf("tokens listed as %v", tokenIds)
+	}
+}
+
+func testERC1155(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	data := customTypes.BlockData{Block: dbtest.Block(100)}
+	for i, tokenId := range []string{"9", "10", "2"} {
+		data.ERC1155Tokens = append(data.ERC1155Tokens, customTypes.ERC1155TokenStruct{ChainId: dbtest.Chain, MintTimestamp: data.Block.Timestamp, MintBlockNumber: 100, MintTxHash: data.Block.Hash, URI: "ipfs://Qm/{id}", TokenId: tokenId, Collection: dbtest.Collection})
+		data.ERC1155Txs = append(data.ERC1155Txs, customTypes.ERC1155TxStruct{ChainId: dbtest.Chain, Timestamp: data.Block.Timestamp, BlockNumber: 100, TxHash: data.Block.Hash, LogIndex: 0, BatchIndex: i, Tag: "mint", Operator: dbtest.Alice, ToAddr: dbtest.Alice, TokenId: tokenId, Amount: "100000000000000000000", Collection: dbtest.Collection})
+	}
+	moved := customTypes.BlockData{Block: dbtest.Block(101)}
+	moved.ERC1155Txs = append(moved.ERC1155Txs, customTypes.ERC1155TxStruct{ChainId: dbtest.Chain, Timestamp: moved.Block.Timestamp, BlockNumber: 101, TxHash: moved.Block.Hash, Tag: "transfer", Operator: dbtest.Alice, FromAddr: dbtest.Alice, ToAddr: dbtest.Bob, TokenId: "10", Amount: "1", Collection: dbtest.Collection})
+	apply(t, store, data, moved)
+
+	tokens, _, err := store.SelectERC1155Tokens(ctx, dbtest.Chain, dbtest.Collection.Hex(), Page{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokenIds []string
+	for _, token := range tokens {
+		tokenIds = append(tokenIds, token.TokenId+":"+token.Supply)
+	}
+	if fmt.Sprint(tokenIds) != "[10:100000000000000000000 9:100000000000000000000 2:100000000000000000000]" {
+		t.Errorf("tokens listed as %v", tokenIds)
+	}
+
+	balances, _, err := store.SelectERC1155Balances(ctx, dbtest.Chain, dbtest.Alice.Hex(), Page{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var held []string
+	for _, balance := range balances {
+		held = append(held, balance.TokenId+":"+balance.Balance)
+	}
+	if fmt.Sprint(held) != "[2:100000000000000000000 9:100000000000000000000 10:99999999999999999999]" {
+		t.Errorf("balances of alice %v", held)
+	}
+
+	txs, _, err := store.SelectERC1155Transfers(ctx, TransferFilter{ChainId: dbtest.Chain, TokenId: "10"}, Page{Limit: 10})
+	if err != nil || len(txs) != 2 || txs[0].Tag != "transfer" || txs[0].Price != "" {
+		t.Errorf("transfers of token 10 %+v: %v", txs, err)
+	}
+	exists, err := store.SelectERC1155CollectionExists(ctx, dbtest.Chain, dbtest.Collection)
+	if err != nil || exists {
+		t.Errorf("collection without deployment exists %v: %v", exists, err)
+	}
+}
+
+func testRollback(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	moved := dbtest.MintBlock(101, "2")
+	moved.Txs = append(moved.Txs, dbtest.Transfer(101, 1, "transfer", dbtest.Alice, dbtest.Bob, "1"))
+	apply(t, store, dbtest.MintBlock(100, "1"), moved)
+
+	err := store.Rollback(ctx, dbtest.Chain, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number := checkpoint(t, store); number != 100 {
+		t.Errorf("checkpoint %d after the rollback, expected 100", number)
+	}
+	nft, found, err := store.SelectNFT(ctx, dbtest.Chain, dbtest.Collection.Hex(), "1")
+	if err != nil || !found || nft.Owner != dbtest.Alice {
+		t.Errorf("owner of token 1 is %s found %v: %v", nft.Owner, found, err)
+	}
+	_, found, err = store.SelectNFT(ctx, dbtest.Chain, dbtest.Collection.Hex(), "2")
+	if err != nil || found {
+		t.Errorf("token minted in the orphaned block found %v: %v", found, err)
+	}
+	_, found, err = store.SelectBlockHash(ctx, dbtest.Chain, 101)
+	if err != nil || found {
+		t.Errorf("orphaned block found %v: %v", found, err)
+	}
+}
+
+// The balances moved in the orphaned blocks are given back, the emptied ones are removed
+func testERC1155Rollback(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	minted := customTypes.BlockData{Block: dbtest.Block(100)}
+	minted.ERC1155Tokens = []customTypes.ERC1155TokenStruct{{ChainId: dbtest.Chain, MintTimestamp: minted.Block.Timestamp, MintBlockNumber: 100, MintTxHash: minted.Block.Hash, URI: "ipfs://Qm/{id}", TokenId: "10", Collection: dbtest.Collection}}
+	minted.ERC1155Txs = []customTypes.ERC1155TxStruct{{ChainId: dbtest.Chain, Timestamp: minted.Block.Timestamp, BlockNumber: 100, TxHash: minted.Block.Hash, Tag: "mint", Operator: dbtest.Alice, ToAddr: dbtest.Alice, TokenId: "10", Amount: "5", Collection: dbtest.Collection}}
+	moved := customTypes.BlockData{Block: dbtest.Block(101)}
+	moved.ERC1155Txs = []customTypes.ERC1155TxStruct{{ChainId: dbtest.Chain, Timestamp: moved.Block.Timestamp, BlockNumber: 101, TxHash: moved.Block.Hash, Tag: "transfer", Operator: dbtest.Alice, FromAddr: dbtest.Alice, ToAddr: dbtest.Bob, TokenId: "10", Amount: "5", Collection: dbtest.Collection}}
+	apply(t, store, minted, moved)
+
+	err := store.Rollback(ctx, dbtest.Chain, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for holder, expected := range map[common.Address]string{dbtest.Alice: "[10:5]", dbtest.Bob: "[]"} {
+		balances, _, err := store.SelectERC1155Balances(ctx, dbtest.Chain, holder.Hex(), Page{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		held := []string{}
+		for _, balance := range balances {
+			held = append(held, balance.TokenId+":"+balance.Balance)
+		}
+		if fmt.Sprint(held) != expected {
+			t.Errorf("balances of %s %v after the rollback, expected %s", holder.Hex(), held, expected)
+		}
+	}
+}
+
+// The traits of the NFTs minted in the orphaned blocks are no longer counted, the emptied counts are removed
+func testTraitRollback(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	apply(t, store, dbtest.MintBlock(100, "1"), dbtest.MintBlock(101, "2"))
+	tasks, err := store.SelectPendingMetadata(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range tasks {
+		traits := []customTypes.TraitStruct{{TraitType: "Background", Value: "Blue"}}
+		if task.TokenId == "2" {
+			traits = append(traits, customTypes.TraitStruct{TraitType: "Eyes", Value: "Green"})
+		}
+		err = store.InsertMetadata(ctx, task, customTypes.MetadataStruct{Name: task.TokenId, Traits: traits, Document: []byte(`{}`)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if counts := traitCounts(t, store); counts != "[Background:Blue:2 Eyes:Green:1]" {
+		t.Fatalf("trait counts %s", counts)
+	}
+
+	err = store.Rollback(ctx, dbtest.Chain, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts := traitCounts(t, store); counts != "[Background:Blue:1]" {
+		t.Errorf("trait counts %s after the rollback", counts)
+	}
+}
+
+func testMetadata(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	apply(t, store, dbtest.MintBlock(100, "1"))
+
+	tasks, err := store.SelectPendingMetadata(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].URI != "ipfs://Qm/1" || tasks[0].Attempts != 0 {
+		t.Fatalf("pending metadata %+v", tasks)
+	}
+	task := tasks[0]
+	document := []byte(`{"name": "One", "attributes": [{"trait_type": "Background", "value": "Blue"}]}`)
+	err = store.InsertMetadata(ctx, task, customTypes.MetadataStruct{
+		Name:       "One",
+		Attributes: []byte(`[{"trait_type": "Background", "value": "Blue"}]`),
+		Traits:     []customTypes.TraitStruct{{TraitType: "Background", Value: "Blue"}},
+		Document:   document,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, err = store.SelectPendingMetadata(ctx, 10)
+	if err != nil || len(tasks) != 0 {
+		t.Errorf("pending metadata %+v after the fetch: %v", tasks, err)
+	}
+
+	nft, _, err := store.SelectNFT(ctx, dbtest.Chain, dbtest.Collection.Hex(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nft.Metadata == nil || nft.Metadata.Status != "done" || nft.Metadata.Name != "One" || nft.Metadata.FetchedAt == nil {
+		t.Errorf("metadata %+v", nft.Metadata)
+	}
+	if counts := traitCounts(t, store); counts != "[Background:Blue:1]" {
+		t.Errorf("trait counts %s", counts)
+	}
+
+	// A new document replaces the traits, one listed twice is counted once
+	err = store.InsertMetadata(ctx, task, customTypes.MetadataStruct{
+		Name:     "One",
+		Traits:   []customTypes.TraitStruct{{TraitType: "Background", Value: "Red"}, {TraitType: "Background", Value: "Red"}, {TraitType: "Eyes", Value: "Green"}},
+		Document: document,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts := traitCounts(t, store); counts != "[Background:Red:1 Eyes:Green:1]" {
+		t.Errorf("trait counts %s after the new document", counts)
+	}
+	stale, err := store.SelectStaleRarity(ctx)
+	if err != nil || len(stale) != 1 || stale[0].Collection != strings.ToLower(dbtest.Collection.Hex()) {
+		t.Errorf("stale rarity %+v: %v", stale, err)
+	}
+}
+
+// Trait counts of the test collection as type:value:count
+func traitCounts(t *testing.T, store Store) string {
+	t.Helper()
+	counts, err := store.SelectTraitCounts(context.Background(), dbtest.Chain, dbtest.Collection.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listed []string
+	for _, count := range counts {
+		listed = append(listed, fmt.Sprintf("%s:%s:%d", count.TraitType, count.Value, count.Count))
+	}
+	return fmt.Sprint(listed)
+}
+
+func testInvalidCursor(t *testing.T, store Store) {
+	_, _, err := store.SelectNFTs(context.Background(), NFTFilter{ChainId: dbtest.Chain}, Page{Limit: 10, Cursor: "not a cursor"})
+	if err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func testReplayedBlock(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	data := dbtest.MintBlock(100, "1")
+	apply(t, store, data, data)
+
+	txs, _, err := store.SelectTransfers(ctx, TransferFilter{ChainId: dbtest.Chain}, Page{Limit: 10})
+	if err != nil || len(txs) != 1 {
+		t.Errorf("%d transfers after a replay: %v", len(txs), err)
+	}
+}
+
+func testCollectionExists(t *testing.T, store Store) {
+	ctx := context.Background()
+	startAt(t, store, 100)
+	data := dbtest.MintBlock(100)
+	data.Collections = []customTypes.ERC721CollectionStruct{dbtest.Deployment(100)}
+	apply(t, store, data)
+
+	exists, err := store.SelectCollectionExists(ctx, dbtest.Chain, dbtest.Collection)
+	if err != nil || !exists {
+		t.Errorf("collection exists %v: %v", exists, err)
+	}
+	collections, err := store.SelectCollections(ctx, dbtest.Chain, []string{dbtest.Collection.Hex()})
+	if err != nil || len(collections) != 1 || collections[0].ContractName != "Test" || collections[0].DeployBlockNumber != 100 {
+		t.Errorf("collections %+v: %v", collections, err)
+	}
+}
+
+func testNFTsByKey(t *testing.T, store Store) {
+	startAt(t, store, 100)
+	apply(t, store, dbtest.MintBlock(100, "1", "2"))
+
+	nfts, err := store.SelectNFTsByKey(context.Background(), dbtest.Chain, []TokenKey{{dbtest.Collection.Hex(), "2"}, {dbtest.Collection.Hex(), "3"}})
+	if err != nil || len(nfts) != 1 || nfts[0].TokenId != "2" {
+		t.Errorf("nfts %+v: %v", nfts, err)
+	}
+}
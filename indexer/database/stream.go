@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"workspace/customTypes"
+
+	"github.com/lib/pq"
+)
+
+// Postgres channel notified with the chain id when the indexer commits or rolls back blocks
+const blocksChannel = "segment_blocks"
+
+// Event of a stream of transfers, Cursor resumes the stream after it
+type StreamEvent struct {
+	Cursor string
+	// Nil for a rollback
+	Transfer *customTypes.ERC721TxStruct
+	// Set when the blocks after this one were rolled back, their canonical transfers follow
+	Rollback *uint64
+}
+
+// Log index after every transfer of a block
+const endOfBlock = math.MaxInt32
+
+// Get the events of the transfers matching filter after the cursor, in block order. Only the blocks up to the checkpoint
+// are read since every block below it is written, the reorganisations recorded since the cursor come first.
+// Without a cursor the stream starts at the checkpoint. next is the cursor of the last event, or from when there is none
+func (s *sqlStore) SelectTransferStream(ctx context.Context, filter TransferFilter, from string, limit int) (events []StreamEvent, next string, err error) {
+	after, first, err := Page{Cursor: from}.after("stream")
+	if err != nil {
+		return nil, "", err
+	}
+	// The reorganisations are read before the checkpoint, a rollback committed in between is seen by the next read
+	if first {
+		after = cursor{Kind: "stream", LogIndex: endOfBlock}
+		err = s.queryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM Reorg WHERE chain_id = $1`, filter.ChainId).Scan(&after.Reorg)
+		if err != nil {
+			return nil, "", err
+		}
+		after.Block, err = s.checkpoint(ctx, filter.ChainId)
+		if err != nil {
+			return nil, "", err
+		}
+		return nil, after.encode(), nil
+	}
+
+	rows, err := s.query(ctx, `SELECT id, ancestor FROM Reorg WHERE chain_id = $1 AND id > $2 ORDER BY id`, filter.ChainId, after.Reorg)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	rolledBack := false
+	for rows.Next() {
+		var ancestor uint64
+		err = rows.Scan(&after.Reorg, &ancestor)
+		if err != nil {
+			return nil, "", err
+		}
+		if ancestor < after.Block {
+			after.Block = ancestor
+			after.LogIndex = endOfBlock
+			rolledBack = true
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+	if rolledBack {
+		ancestor := after.Block
+		events = append(events, StreamEvent{Cursor: after.encode(), Rollback: &ancestor})
+	}
+
+	checkpoint, err := s.checkpoint(ctx, filter.ChainId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := filter.query(txColumns(s), "ERC721Tx")
+	q.sql += ` AND (block_number, log_index) > (` + q.arg(after.Block) + `, ` + q.arg(after.LogIndex) + `) AND block_number <= ` + q.arg(checkpoint) + `
+	ORDER BY block_number, log_index LIMIT ` + q.arg(limit)
+	txRows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer txRows.Close()
+	txs, err := scanTransfers(txRows)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range txs {
+		after.Block = txs[i].BlockNumber
+		after.LogIndex = txs[i].LogIndex
+		events = append(events, StreamEvent{Cursor: after.encode(), Transfer: &txs[i]})
+	}
+	return events, after.encode(), nil
+}
+
+// Last block of the run of indexed blocks of a chain, 0 before the first one
+func (s *sqlStore) checkpoint(ctx context.Context, chainId uint64) (block uint64, err error) {
+	err = s.queryRow(ctx, `SELECT block FROM State WHERE chain_id = $1`, chainId).Scan(&block)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return block, err
+}
+
+// Wake the streams of the API once the transaction is committed
+func (w writer) notifyBlocks(chainId uint64) error {
+	query := w.notify(blocksChannel)
+	if query == "" {
+		return nil
+	}
+	_, err := w.exec(query, strconv.FormatUint(chainId, 10))
+	return err
+}
+
+// Get a value each time the indexer may have written blocks, until ctx is done.
+// Postgres notifies the commits, SQLite has no notifications and is polled every interval instead
+func WatchBlocks(ctx context.Context, driver string, uri string, interval time.Duration) (<-chan struct{}, error) {
+	changed := make(chan struct{}, 1)
+	wake := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	if driver != "postgres" {
+		go func() {
+			defer close(changed)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					wake()
+				}
+			}
+		}()
+		return changed, nil
+	}
+
+	listener := pq.NewListener(uri, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("Block notifications:", err)
+		}
+	})
+	err := listener.Listen(blocksChannel)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	go func() {
+		defer close(changed)
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			// nil after a reconnection, the notifications sent meanwhile are lost
+			case <-listener.Notify:
+				wake()
+			// Detect a dead connection when nothing is indexed
+			case <-time.After(90 * time.Second):
+				go listener.Ping()
+			}
+		}
+	}()
+	return changed, nil
+}
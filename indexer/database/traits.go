@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+
+	"workspace/customTypes"
+)
+
+// Replace the traits of a token, the trait counts of its collection follow and its rarity becomes stale
+func (w writer) replaceTraits(task customTypes.MetadataTask, traits []customTypes.TraitStruct) (err error) {
+	for _, query := range []string{
+		`UPDATE ERC721TraitCount AS c SET count = c.count - 1 FROM ERC721Attribute a
+		WHERE a.chain_id = $1 AND a.collection = $2 AND a.token_id = $3
+		AND c.chain_id = a.chain_id AND c.collection = a.collection AND c.trait_type = a.trait_type AND c.value = a.value`,
+		`DELETE FROM ERC721Attribute WHERE chain_id = $1 AND collection = $2 AND token_id = $3`,
+	} {
+		_, err = w.exec(query, task.ChainId, task.Collection, task.TokenId)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = w.exec(`INSERT INTO ERC721RarityStale(chain_id, collection) VALUES ($1, $2) ON CONFLICT DO NOTHING`, task.ChainId, task.Collection)
+	if err != nil {
+		return err
+	}
+
+	for _, trait := range traits {
+		result, err := w.exec(`INSERT INTO ERC721Attribute(chain_id, collection, token_id, trait_type, value) VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+			task.ChainId, task.Collection, task.TokenId, trait.TraitType, trait.Value)
+		if err != nil {
+			return err
+		}
+		// A trait listed twice in a document is only counted once
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if inserted == 0 {
+			continue
+		}
+		_, err = w.exec(`INSERT INTO ERC721TraitCount(chain_id, collection, trait_type, value, count) VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (chain_id, collection, trait_type, value) DO UPDATE SET count = ERC721TraitCount.count + 1`,
+			task.ChainId, task.Collection, trait.TraitType, trait.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.exec(`DELETE FROM ERC721TraitCount WHERE chain_id = $1 AND collection = $2 AND count <= 0`, task.ChainId, task.Collection)
+	return err
+}
+
+// Collection whose rarity must be computed again
+type StaleCollection struct {
+	ChainId    uint64
+	Collection string
+}
+
+// Get the collections whose rarity must be computed again
+func (s *sqlStore) SelectStaleRarity(ctx context.Context) (collections []StaleCollection, err error) {
+	rows, err := s.query(ctx, `SELECT chain_id, collection FROM ERC721RarityStale`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var collection StaleCollection
+		err = rows.Scan(&collection.ChainId, &collection.Collection)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	return collections, rows.Err()
+}
+
+// Compute the rarity of every token of a collection whose metadata is fetched.
+// The score is the information content of the traits of a token plus the one of its number of traits:
+// a trait shared by count tokens out of total is worth -ln(count / total).
+func (s *sqlStore) UpdateRarity(ctx context.Context, chainId uint64, collection string) (err error) {
+	return s.inTx(ctx, func(w writer) error {
+		// Traits written from now on mark the collection stale again
+		_, err := w.exec(`DELETE FROM ERC721RarityStale WHERE chain_id = $1 AND collection = $2`, chainId, collection)
+		if err != nil {
+			return err
+		}
+		_, err = w.exec(`DELETE FROM ERC721Rarity WHERE chain_id = $1 AND collection = $2`, chainId, collection)
+		if err != nil {
+			return err
+		}
+
+		updateRarity := `WITH tokens AS (
+		SELECT token_id FROM ERC721Metadata WHERE chain_id = $1 AND collection = $2 AND status = 'done'
+	), total AS (
+		SELECT CAST(COUNT(*) AS double precision) AS n FROM tokens
+	), trait_scores AS (
+		SELECT a.token_id, SUM(-ln(c.count / total.n)) AS score, COUNT(*) AS traits
+		FROM ERC721Attribute a
+		JOIN ERC721TraitCount c ON c.chain_id = a.chain_id AND c.collection = a.collection AND c.trait_type = a.trait_type AND c.value = a.value
+		CROSS JOIN total
+		WHERE a.chain_id = $1 AND a.collection = $2
+		GROUP BY a.token_id
+	), token_scores AS (
+		SELECT t.token_id, COALESCE(s.score, 0) AS score, COALESCE(s.traits, 0) AS traits
+		FROM tokens t LEFT JOIN trait_scores s ON s.token_id = t.token_id
+	), trait_count_frequency AS (
+		SELECT traits, CAST(COUNT(*) AS double precision) AS n FROM token_scores GROUP BY traits
+	), scores AS (
+		SELECT s.token_id, s.score - ln(f.n / total.n) AS score, s.traits
+		FROM token_scores s JOIN trait_count_frequency f ON f.traits = s.traits CROSS JOIN total
+	)
+	INSERT INTO ERC721Rarity(chain_id, collection, token_id, score, trait_count, rank)
+	SELECT $1, $2, token_id, score, traits, RANK() OVER (ORDER BY score DESC) FROM scores`
+		_, err = w.exec(updateRarity, chainId, collection)
+		return err
+	})
+}
+
+// Queries removing the traits of the NFTs minted above $2 with their metadata, before the NFTs are deleted.
+// The trait counts they empty are removed by emptyTraitCounts
+var metadataRollback = []string{
+	`UPDATE ERC721TraitCount AS c SET count = c.count - d.n FROM (
+		SELECT a.chain_id, a.collection, a.trait_type, a.value, COUNT(*) AS n FROM ERC721Attribute a
+		JOIN ERC721 n ON n.chain_id = a.chain_id AND n.collection = a.collection AND n.token_id = a.token_id
+		WHERE n.chain_id = $1 AND n.mint_block_number > $2
+		GROUP BY a.chain_id, a.collection, a.trait_type, a.value
+	) d WHERE c.chain_id = d.chain_id AND c.collection = d.collection AND c.trait_type = d.trait_type AND c.value = d.value`,
+	`INSERT INTO ERC721RarityStale(chain_id, collection)
+	SELECT DISTINCT chain_id, collection FROM ERC721 WHERE chain_id = $1 AND mint_block_number > $2
+	ON CONFLICT DO NOTHING`,
+	`DELETE FROM ERC721Attribute WHERE chain_id = $1 AND EXISTS (
+		SELECT 1 FROM ERC721 n WHERE n.chain_id = ERC721Attribute.chain_id AND n.collection = ERC721Attribute.collection
+		AND n.token_id = ERC721Attribute.token_id AND n.mint_block_number > $2
+	)`,
+	`DELETE FROM ERC721Metadata WHERE chain_id = $1 AND EXISTS (
+		SELECT 1 FROM ERC721 n WHERE n.chain_id = ERC721Metadata.chain_id AND n.collection = ERC721Metadata.collection
+		AND n.token_id = ERC721Metadata.token_id AND n.mint_block_number > $2
+	)`,
+}
+
+// Removes the trait counts emptied by a rollback, it only takes the chain as $1
+const emptyTraitCounts = `DELETE FROM ERC721TraitCount WHERE chain_id = $1 AND count <= 0`
@@ -0,0 +1,286 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"workspace/customTypes"
+)
+
+func webhookColumns(d dialect) string {
+	return `id, chain_id, url, COALESCE(collection, ''), COALESCE(address, ''), COALESCE(tag, ''), active, cursor, ` + d.epoch("created_at")
+}
+
+func scanWebhooks(rows *sql.Rows) ([]customTypes.WebhookStruct, error) {
+	webhooks := []customTypes.WebhookStruct{}
+	for rows.Next() {
+		var webhook customTypes.WebhookStruct
+		err := rows.Scan(&webhook.Id, &webhook.ChainId, &webhook.URL, &webhook.Collection, &webhook.Address, &webhook.Tag, &webhook.Active, &webhook.Cursor, &webhook.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// Transfers sent to a webhook
+func WebhookFilter(webhook customTypes.WebhookStruct) TransferFilter {
+	return TransferFilter{ChainId: webhook.ChainId, Collection: webhook.Collection, Address: webhook.Address, Tag: webhook.Tag}
+}
+
+// Register an active webhook, it gets the transfers indexed from now on
+func (s *sqlStore) InsertWebhook(ctx context.Context, webhook customTypes.WebhookStruct) (customTypes.WebhookStruct, error) {
+	_, start, err := s.SelectTransferStream(ctx, WebhookFilter(webhook), "", 1)
+	if err != nil {
+		return webhook, err
+	}
+	webhook.Collection = strings.ToLower(webhook.Collection)
+	webhook.Address = strings.ToLower(webhook.Address)
+	webhook.Active = true
+	webhook.Cursor = start
+	webhook.CreatedAt = time.Now().Unix()
+
+	insertWebhook := `INSERT INTO Webhook(chain_id, url, collection, address, tag, secret, active, cursor, created_at)
+	VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, ` + s.time("$9") + `) RETURNING id`
+	err = s.queryRow(ctx, insertWebhook, webhook.ChainId, webhook.URL, webhook.Collection, webhook.Address, webhook.Tag, webhook.Secret, webhook.Active, webhook.Cursor, webhook.CreatedAt).Scan(&webhook.Id)
+	return webhook, err
+}
+
+// Get a page of the webhooks in the order they were created, without their secret
+func (s *sqlStore) SelectWebhooks(ctx context.Context, page Page) (webhooks []customTypes.WebhookStruct, next string, err error) {
+	after, first, err := page.after("webhook")
+	if err != nil {
+		return nil, "", err
+	}
+	q := &listQuery{sql: `SELECT ` + webhookColumns(s) + ` FROM Webhook`}
+	if !first {
+		q.sql += ` WHERE id > ` + q.arg(after.Id)
+	}
+	q.sql += ` ORDER BY id`
+	q.limit(page)
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	webhooks, err = scanWebhooks(rows)
+	if err != nil {
+		return nil, "", err
+	}
+	webhooks, next = pageRows(webhooks, page.Limit, func(webhook customTypes.WebhookStruct) cursor {
+		return cursor{Kind: "webhook", Id: webhook.Id}
+	})
+	return webhooks, next, nil
+}
+
+// Get a webhook without its secret, found is false for an unknown id
+func (s *sqlStore) SelectWebhook(ctx context.Context, id int64) (webhook customTypes.WebhookStruct, found bool, err error) {
+	rows, err := s.query(ctx, `SELECT `+webhookColumns(s)+` FROM Webhook WHERE id = $1`, id)
+	if err != nil {
+		return webhook, false, err
+	}
+	defer rows.Close()
+	webhooks, err := scanWebhooks(rows)
+	if err != nil || len(webhooks) == 0 {
+		return webhook, false, err
+	}
+	return webhooks[0], true, nil
+}
+
+// Get the webhooks whose events are queued
+func (s *sqlStore) SelectActiveWebhooks(ctx context.Context) ([]customTypes.WebhookStruct, error) {
+	rows, err := s.query(ctx, `SELECT `+webhookColumns(s)+` FROM Webhook WHERE active = $1 ORDER BY id`, true)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanWebhooks(rows)
+}
+
+// Stop queuing the events of a webhook and sending its pending deliveries, found is false for an unknown id
+func (s *sqlStore) DisableWebhook(ctx context.Context, id int64) (found bool, err error) {
+	result, err := s.exec(ctx, `UPDATE Webhook SET active = $2 WHERE id = $1`, id, false)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// Queue the events read from the cursor of a webhook and move it to next in one transaction.
+// queued is false when the webhook was disabled or its cursor moved since it was read, nothing is written then
+func (s *sqlStore) QueueWebhookDeliveries(ctx context.Context, webhook customTypes.WebhookStruct, next string, deliveries []customTypes.WebhookDeliveryStruct) (queued bool, err error) {
+	err = s.inTx(ctx, func(w writer) error {
+		result, err := w.exec(`UPDATE Webhook SET cursor = $3 WHERE id = $1 AND cursor = $2 AND active = $4`, webhook.Id, webhook.Cursor, next, true)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil || affected == 0 {
+			return err
+		}
+		now := time.Now().Unix()
+		for _, delivery := range deliveries {
+			_, err = w.exec(`INSERT INTO WebhookDelivery(webhook_id, event, payload, status, attempts, next_attempt, created_at) VALUES ($1, $2, $3, 'pending', 0, `+w.time("$4")+`, `+w.time("$4")+`)`,
+				webhook.Id, delivery.Event, string(delivery.Payload), now)
+			if err != nil {
+				return err
+			}
+		}
+		queued = true
+		return nil
+	})
+	return queued, err
+}
+
+// Insert an event sent at once, like a test, the worker does not pick it up. found is false for an unknown webhook
+func (s *sqlStore) InsertWebhookDelivery(ctx context.Context, webhookId int64, event string, payload []byte) (task customTypes.WebhookTask, found bool, err error) {
+	err = s.queryRow(ctx, `SELECT url, secret FROM Webhook WHERE id = $1`, webhookId).Scan(&task.URL, &task.Secret)
+	if err == sql.ErrNoRows {
+		return task, false, nil
+	}
+	if err != nil {
+		return task, false, err
+	}
+
+	now := time.Now().Unix()
+	task.WebhookDeliveryStruct = customTypes.WebhookDeliveryStruct{WebhookId: webhookId, Event: event, Payload: payload, Status: "sending", NextAttempt: now, CreatedAt: now}
+	insertDelivery := `INSERT INTO WebhookDelivery(webhook_id, event, payload, status, attempts, next_attempt, created_at) VALUES ($1, $2, $3, 'sending', 0, ` + s.time("$4") + `, ` + s.time("$4") + `) RETURNING id`
+	err = s.queryRow(ctx, insertDelivery, webhookId, event, string(payload), now).Scan(&task.Id)
+	return task, err == nil, err
+}
+
+// Claim the pending deliveries of the active webhooks that are due, the oldest first. Their next attempt is moved to the end
+// of the lease so that the other processes skip them while they are sent, they are sent again if the lease ends first
+func (s *sqlStore) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) (tasks []customTypes.WebhookTask, err error) {
+	err = s.inTx(ctx, func(w writer) error {
+		now := time.Now()
+		rows, err := w.query(`SELECT d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, `+w.epoch("d.next_attempt")+`, `+w.epoch("d.created_at")+`, w.url, w.secret
+		FROM WebhookDelivery d JOIN Webhook w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt <= `+w.time("$2")+` AND w.active = $3
+		ORDER BY d.next_attempt, d.id LIMIT $1`+w.skipLocked("d"), limit, now.Unix(), true)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var task customTypes.WebhookTask
+			var payload string
+			err = rows.Scan(&task.Id, &task.WebhookId, &task.Event, &payload, &task.Status, &task.Attempts, &task.NextAttempt, &task.CreatedAt, &task.URL, &task.Secret)
+			if err != nil {
+				return err
+			}
+			task.Payload = []byte(payload)
+			tasks = append(tasks, task)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+
+		leaseEnd := now.Add(lease).Unix()
+		for i := range tasks {
+			_, err = w.exec(`UPDATE WebhookDelivery SET next_attempt = `+w.time("$2")+` WHERE id = $1`, tasks[i].Id, leaseEnd)
+			if err != nil {
+				return err
+			}
+			tasks[i].NextAttempt = leaseEnd
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Record a request sent for a delivery and its new status, it is sent again at nextAttempt while it is pending
+func (s *sqlStore) InsertWebhookAttempt(ctx context.Context, task customTypes.WebhookTask, attempt customTypes.WebhookAttemptStruct, status string, nextAttempt time.Time) error {
+	return s.inTx(ctx, func(w writer) error {
+		insertAttempt := `INSERT INTO WebhookAttempt(delivery_id, attempted_at, status_code, error, duration_ms) VALUES ($1, ` + w.time("$2") + `, $3, NULLIF($4, ''), $5)`
+		_, err := w.exec(insertAttempt, task.Id, attempt.AttemptedAt, attempt.StatusCode, attempt.Error, attempt.DurationMs)
+		if err != nil {
+			return err
+		}
+		_, err = w.exec(`UPDATE WebhookDelivery SET status = $2, attempts = attempts + 1, next_attempt = `+w.time("$3")+` WHERE id = $1`, task.Id, status, nextAttempt.Unix())
+		return err
+	})
+}
+
+// Get a page of the deliveries of a webhook with their attempts, the last first
+func (s *sqlStore) SelectWebhookDeliveries(ctx context.Context, webhookId int64, page Page) (deliveries []customTypes.WebhookDeliveryStruct, next string, err error) {
+	after, first, err := page.after("webhook-delivery")
+	if err != nil {
+		return nil, "", err
+	}
+	q := &listQuery{}
+	q.sql = `SELECT id, webhook_id, event, payload, status, attempts, ` + s.epoch("next_attempt") + `, ` + s.epoch("created_at") + ` FROM WebhookDelivery WHERE webhook_id = ` + q.arg(webhookId)
+	if !first {
+		q.sql += ` AND id < ` + q.arg(after.Id)
+	}
+	q.sql += ` ORDER BY id DESC`
+	q.limit(page)
+	rows, err := s.query(ctx, q.sql, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	deliveries = []customTypes.WebhookDeliveryStruct{}
+	for rows.Next() {
+		var delivery customTypes.WebhookDeliveryStruct
+		var payload string
+		err = rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.Event, &payload, &delivery.Status, &delivery.Attempts, &delivery.NextAttempt, &delivery.CreatedAt)
+		if err != nil {
+			return nil, "", err
+		}
+		delivery.Payload = []byte(payload)
+		delivery.History = []customTypes.WebhookAttemptStruct{}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+	deliveries, next = pageRows(deliveries, page.Limit, func(delivery customTypes.WebhookDeliveryStruct) cursor {
+		return cursor{Kind: "webhook-delivery", Id: delivery.Id}
+	})
+	if len(deliveries) == 0 {
+		return deliveries, next, nil
+	}
+
+	// Attempts of the page in one query
+	byId := map[int64]*customTypes.WebhookDeliveryStruct{}
+	attemptsQuery := &listQuery{}
+	ids := make([]string, len(deliveries))
+	for i := range deliveries {
+		byId[deliveries[i].Id] = &deliveries[i]
+		ids[i] = attemptsQuery.arg(deliveries[i].Id)
+	}
+	attemptsQuery.sql = `SELECT delivery_id, ` + s.epoch("attempted_at") + `, status_code, COALESCE(error, ''), duration_ms FROM WebhookAttempt
+	WHERE delivery_id IN (` + strings.Join(ids, ", ") + `) ORDER BY id`
+	attempts, err := s.query(ctx, attemptsQuery.sql, attemptsQuery.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer attempts.Close()
+	for attempts.Next() {
+		var deliveryId int64
+		var attempt customTypes.WebhookAttemptStruct
+		var statusCode sql.NullInt64
+		err = attempts.Scan(&deliveryId, &attempt.AttemptedAt, &statusCode, &attempt.Error, &attempt.DurationMs)
+		if err != nil {
+			return nil, "", err
+		}
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			attempt.StatusCode = &code
+		}
+		delivery := byId[deliveryId]
+		delivery.History = append(delivery.History, attempt)
+	}
+	return deliveries, next, attempts.Err()
+}
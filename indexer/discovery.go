@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"math/big"
+
+	"workspace/customTypes"
+	"workspace/rpcclient"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/metachris/eth-go-bindings/erc1155"
+	"github.com/metachris/eth-go-bindings/erc721"
+)
+
+// Address of the contract created by a top-level creation transaction
+func creationAddress(tx *types.Transaction) (common.Address, error) {
+	signer := types.LatestSignerForChainID(tx.ChainId())
+	sender, err := signer.Sender(tx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.CreateAddress(sender, tx.Nonce()), nil
+}
+
+// Check if a collection is already recorded in data, in the database, in a block already ordered or was already checked
+func (ix *chainIndexer) collectionKnown(addr common.Address, data *customTypes.BlockData) (bool, error) {
+	if _, checked := ix.collections.Load(addr); checked {
+		return true, nil
+	}
+	for _, collection := range data.Collections {
+		if collection.ContractAddress == addr {
+			return true, nil
+		}
+	}
+	for _, collection := range data.ERC1155Collections {
+		if collection.ContractAddress == addr {
+			return true, nil
+		}
+	}
+
+	known, err := ix.store.SelectCollectionExists(context.Background(), ix.chain.ChainID, addr)
+	if err == nil && !known {
+		known, err = ix.store.SelectERC1155CollectionExists(context.Background(), ix.chain.ChainID, addr)
+	}
+	if known {
+		ix.collections.Store(addr, true)
+	}
+	return known, err
+}
+
+// Mark the collections recorded in data as known. The blocks are analyzed in parallel, so this is only done in block order:
+// a block analyzed before the one deploying a collection must not claim it with its own block as the deployment
+func (ix *chainIndexer) rememberCollections(data *customTypes.BlockData) {
+	for _, collection := range data.Collections {
+		ix.collections.Store(collection.ContractAddress, true)
+	}
+	for _, collection := range data.ERC1155Collections {
+		ix.collections.Store(collection.ContractAddress, true)
+	}
+}
+
+// Forget the checked contracts, the collections of the rolled back blocks must be found again
+func (ix *chainIndexer) forgetCollections() {
+	ix.collections.Range(func(addr, _ any) bool {
+		ix.collections.Delete(addr)
+		return true
+	})
+}
+
+// Record the collection of an unknown contract from its first transfer, whoever deployed it.
+// receipt is the receipt of the transaction of the log when it was already fetched.
+func (ix *chainIndexer) discoverCollection(vLog *types.Log, receipt *types.Receipt, data *customTypes.BlockData) error {
+	transfer := vLog.Topics[0] == EVT_TRANSFER && len(vLog.Topics) > 3
+	if !transfer && vLog.Topics[0] != EVT_TRANSFER_SINGLE && vLog.Topics[0] != EVT_TRANSFER_BATCH {
+		return nil
+	}
+	known, err := ix.collectionKnown(vLog.Address, data)
+	if err != nil || known {
+		return err
+	}
+
+	// Contracts that do not implement ERC-165 are recognized by answering the standard calls.
+	// A contract is only remembered as not being a collection when the node answered, the block is retried otherwise
+	standard, err := contractStandard(vLog.Address, ix.client)
+	if rpcclient.IsTransient(err) {
+		return err
+	}
+	if err != nil || standard == "" {
+		standard, err = ix.guessStandard(vLog, transfer)
+		if err != nil {
+			return err
+		}
+	}
+	if standard == "" {
+		ix.collections.Store(vLog.Address, false)
+		return nil
+	}
+
+	blockNumber, timestamp, deployTx, err := ix.deploymentOf(vLog, receipt, data)
+	if err != nil {
+		return err
+	}
+	return ix.addCollection(vLog.Address, standard, deployTx, timestamp, blockNumber, data)
+}
+
+// Guess the standard of a contract without ERC-165 from the calls it answers at the block of the log.
+// The standard is empty when the contract rejects them, err is only set when the node did not answer
+func (ix *chainIndexer) guessStandard(vLog *types.Log, transfer bool) (standard string, err error) {
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(vLog.BlockNumber)}
+	if transfer {
+		contract, err := erc721.NewErc721Caller(vLog.Address, ix.client)
+		if err != nil {
+			return "", nil
+		}
+		offset := len(vLog.Topics) - 4
+		_, err = contract.OwnerOf(opts, vLog.Topics[3+offset].Big())
+		if err != nil {
+			return "", transientOnly(err)
+		}
+		return "erc721", nil
+	}
+
+	contract, err := erc1155.NewErc1155Caller(vLog.Address, ix.client)
+	if err != nil {
+		return "", nil
+	}
+	_, err = contract.BalanceOf(opts, common.Address{}, common.Big0)
+	if err != nil {
+		return "", transientOnly(err)
+	}
+	return "erc1155", nil
+}
+
+// Keep the errors of the requests the node did not answer, the others are answers
+func transientOnly(err error) error {
+	if rpcclient.IsTransient(err) {
+		return err
+	}
+	return nil
+}
+
+// Find where a discovered collection was deployed. The transaction of the log is used when it created the contract,
+// otherwise the deployment block is searched when backfill_deploy is set. The first block seen is used when nothing is found.
+func (ix *chainIndexer) deploymentOf(vLog *types.Log, receipt *types.Receipt, data *customTypes.BlockData) (uint64, uint64, common.Hash, error) {
+	if receipt == nil {
+		var err error
+		receipt, err = ix.client.TransactionReceipt(context.Background(), vLog.TxHash)
+		if err != nil {
+			return 0, 0, common.Hash{}, err
+		}
+	}
+	if receipt.ContractAddress == vLog.Address {
+		return data.Block.Number, data.Block.Timestamp, vLog.TxHash, nil
+	}
+
+	if ix.chain.BackfillDeploy {
+		blockNumber, timestamp, deployTx, err := ix.searchDeployment(vLog.Address, vLog.BlockNumber)
+		if err == nil {
+			return blockNumber, timestamp, deployTx, nil
+		}
+		ix.log.Println("Deployment of", vLog.Address.Hex(), "not found :", err)
+	}
+	return data.Block.Number, data.Block.Timestamp, common.Hash{}, nil
+}
+
+// Binary search the first block where the contract has code, then look for the transaction that created it
+func (ix *chainIndexer) searchDeployment(addr common.Address, seen uint64) (uint64, uint64, common.Hash, error) {
+	low, high := uint64(0), seen
+	for low < high {
+		middle := low + (high-low)/2
+		code, err := ix.client.CodeAt(context.Background(), addr, new(big.Int).SetUint64(middle))
+		if err != nil {
+			return 0, 0, common.Hash{}, err
+		}
+		if len(code) > 0 {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+
+	block, err := ix.client.BlockByNumber(context.Background(), new(big.Int).SetUint64(low))
+	if err != nil {
+		return 0, 0, common.Hash{}, err
+	}
+	for _, tx := range block.Transactions() {
+		if tx.To() != nil {
+			continue
+		}
+		created, err := creationAddress(tx)
+		if err == nil && created == addr {
+			return low, block.Time(), tx.Hash(), nil
+		}
+	}
+	// Created by another contract, only a trace gives the transaction
+	if ix.chain.TraceMethod != rpcclient.TraceNone {
+		creations, err := ix.client.BlockCreations(context.Background(), ix.chain.TraceMethod, low)
+		if err != nil {
+			return 0, 0, common.Hash{}, err
+		}
+		for _, creation := range creations {
+			if creation.Address == addr {
+				return low, block.Time(), creation.TxHash, nil
+			}
+		}
+	}
+	return low, block.Time(), common.Hash{}, nil
+}
+
+// Record the collections created by the contracts of a block, traces are only read when trace_method is set
+func (ix *chainIndexer) traceCreations(data *customTypes.BlockData) error {
+	if ix.chain.TraceMethod == rpcclient.TraceNone {
+		return nil
+	}
+	creations, err := ix.client.BlockCreations(context.Background(), ix.chain.TraceMethod, data.Block.Number)
+	if err != nil {
+		return err
+	}
+	for _, creation := range creations {
+		known, err := ix.collectionKnown(creation.Address, data)
+		if err != nil {
+			return err
+		}
+		if known {
+			continue
+		}
+		// Contracts without ERC-165 are left to their first transfer
+		standard, err := contractStandard(creation.Address, ix.client)
+		if err != nil || standard == "" {
+			continue
+		}
+		err = ix.addCollection(creation.Address, standard, creation.TxHash, data.Block.Timestamp, data.Block.Number, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
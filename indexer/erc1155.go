@@ -0,0 +1,119 @@
+package main
+
+import (
+	"math/big"
+
+	"workspace/customTypes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/metachris/eth-go-bindings/erc1155"
+)
+
+var EVT_TRANSFER_SINGLE = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+var EVT_TRANSFER_BATCH = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+var EVT_URI = crypto.Keccak256Hash([]byte("URI(string,uint256)"))
+
+// Only used to decode the events, it is not bound to a contract
+var erc1155Events, _ = erc1155.NewErc1155Filterer(common.Address{}, nil)
+
+// Decode the ERC-1155 TransferSingle, TransferBatch and URI logs, the transfers are priced with the block
+func (ix *chainIndexer) erc1155Checker(vLog *types.Log, timestamp uint64, data *customTypes.BlockData) error {
+	if len(vLog.Topics) == 0 {
+		return nil
+	}
+
+	switch vLog.Topics[0] {
+	case EVT_TRANSFER_SINGLE:
+		event, err := erc1155Events.ParseTransferSingle(*vLog)
+		if err != nil {
+			// Not a standard TransferSingle, the topics do not match the signature
+			return nil
+		}
+		return ix.erc1155Transfers(vLog, timestamp, event.Operator, event.From, event.To, []*big.Int{event.Id}, []*big.Int{event.Value}, data)
+	case EVT_TRANSFER_BATCH:
+		event, err := erc1155Events.ParseTransferBatch(*vLog)
+		if err != nil || len(event.Ids) != len(event.Values) {
+			return nil
+		}
+		return ix.erc1155Transfers(vLog, timestamp, event.Operator, event.From, event.To, event.Ids, event.Values, data)
+	case EVT_URI:
+		event, err := erc1155Events.ParseURI(*vLog)
+		if err != nil {
+			return nil
+		}
+		data.ERC1155Uris = append(data.ERC1155Uris, customTypes.ERC1155UriStruct{
+			ChainId:     ix.chain.ChainID,
+			BlockNumber: vLog.BlockNumber,
+			TxHash:      vLog.TxHash.Hex(),
+			LogIndex:    vLog.Index,
+			TokenId:     event.Id.String(),
+			Collection:  vLog.Address,
+			URI:         event.Value,
+		})
+	}
+	return nil
+}
+
+// Record one transfer per id, the first mint of an id also records the token
+func (ix *chainIndexer) erc1155Transfers(vLog *types.Log, timestamp uint64, operator common.Address, from common.Address, to common.Address, ids []*big.Int, amounts []*big.Int, data *customTypes.BlockData) error {
+	txTag := "transfer"
+	if from == (common.Address{}) {
+		txTag = "mint"
+	} else if to == (common.Address{}) {
+		txTag = "burn"
+	}
+
+	for i, id := range ids {
+		tx := customTypes.ERC1155TxStruct{
+			ChainId:     ix.chain.ChainID,
+			Timestamp:   timestamp,
+			BlockNumber: vLog.BlockNumber,
+			TxHash:      vLog.TxHash.Hex(),
+			LogIndex:    vLog.Index,
+			BatchIndex:  i,
+			Tag:         txTag,
+			Operator:    operator,
+			FromAddr:    from,
+			ToAddr:      to,
+			TokenId:     id.String(),
+			Amount:      amounts[i].String(),
+			Collection:  vLog.Address,
+		}
+		data.ERC1155Txs = append(data.ERC1155Txs, tx)
+
+		if txTag == "mint" && !hasERC1155Token(data, tx.Collection, tx.TokenId) {
+			contract, err := erc1155.NewErc1155Caller(tx.Collection, ix.client)
+			if err != nil {
+				return err
+			}
+			// The metadata extension is optional
+			uri, err := contract.Uri(nil, id)
+			if err != nil {
+				uri = ""
+			}
+
+			data.ERC1155Tokens = append(data.ERC1155Tokens, customTypes.ERC1155TokenStruct{
+				ChainId:         ix.chain.ChainID,
+				MintTimestamp:   timestamp,
+				MintBlockNumber: vLog.BlockNumber,
+				MintTxHash:      tx.TxHash,
+				URI:             uri,
+				TokenId:         tx.TokenId,
+				Collection:      tx.Collection,
+			})
+		}
+	}
+	return nil
+}
+
+// Only the first mint of an id in a block reads its uri
+func hasERC1155Token(data *customTypes.BlockData, collection common.Address, tokenId string) bool {
+	for _, token := range data.ERC1155Tokens {
+		if token.Collection == collection && token.TokenId == tokenId {
+			return true
+		}
+	}
+	return false
+}
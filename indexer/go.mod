@@ -5,7 +5,9 @@ go 1.20
 require (
 	github.com/ethereum/go-ethereum v1.12.0
 	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.22
 	github.com/metachris/eth-go-bindings v0.5.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
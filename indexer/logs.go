@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"math/big"
+
+	"workspace/customTypes"
+	"workspace/rpcclient"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Events read in logs mode
+func (ix *chainIndexer) eventTopics() [][]common.Hash {
+	events := []common.Hash{EVT_TRANSFER, EVT_TRANSFER_SINGLE, EVT_TRANSFER_BATCH, EVT_URI}
+	return [][]common.Hash{append(events, ix.sales.Topics()...)}
+}
+
+// Group the indexed logs by block. Only the transactions carrying an NFT event are fetched,
+// and a receipt only when the log comes from an unknown collection.
+func (ix *chainIndexer) logsAnalizer(logs []types.Log, known *types.Header) ([]customTypes.BlockData, error) {
+	var blocks []customTypes.BlockData
+	txs := map[common.Hash]*types.Transaction{}
+	// ERC-20 transfers match the Transfer topic too, they are kept to price the NFT transfers
+	erc20 := map[common.Hash][]*types.Log{}
+
+	for i := range logs {
+		vLog := &logs[i]
+		if vLog.Removed {
+			continue
+		}
+		if _, _, _, ok := erc20Transfer(vLog); ok {
+			erc20[vLog.TxHash] = append(erc20[vLog.TxHash], vLog)
+			continue
+		}
+		// Other logs with the Transfer topic and fewer topics are not NFT transfers
+		if vLog.Topics[0] == EVT_TRANSFER && len(vLog.Topics) <= 3 {
+			continue
+		}
+
+		if len(blocks) == 0 || blocks[len(blocks)-1].Block.Number != vLog.BlockNumber {
+			// The logs come in block order, the collections of the previous block are found before the next ones
+			if len(blocks) > 0 {
+				ix.rememberCollections(&blocks[len(blocks)-1])
+			}
+			header := known
+			if header == nil || header.Hash() != vLog.BlockHash {
+				var err error
+				header, err = ix.client.HeaderByHash(context.Background(), vLog.BlockHash)
+				if err != nil {
+					return nil, err
+				}
+			}
+			blocks = append(blocks, customTypes.BlockData{Block: ix.blockStruct(header)})
+		}
+		data := &blocks[len(blocks)-1]
+
+		tx, ok := txs[vLog.TxHash]
+		if !ok {
+			var err error
+			tx, _, err = ix.client.TransactionByHash(context.Background(), vLog.TxHash)
+			if err != nil {
+				return nil, err
+			}
+			txs[vLog.TxHash] = tx
+		}
+
+		err := ix.discoverCollection(vLog, nil, data)
+		if err != nil {
+			return nil, err
+		}
+		err = ix.transferChecker(vLog, data.Block.Timestamp, data)
+		if err != nil {
+			return nil, err
+		}
+		err = ix.erc1155Checker(vLog, data.Block.Timestamp, data)
+		if err != nil {
+			return nil, err
+		}
+		ix.saleChecker(vLog, data.Block.Timestamp, data)
+	}
+
+	payments := map[common.Hash]*payment{}
+	for hash, tx := range txs {
+		payments[hash] = &payment{tx: tx, erc20: erc20[hash]}
+	}
+	for i := range blocks {
+		ix.priceTransfers(&blocks[i], payments)
+	}
+	return blocks, nil
+}
+
+// Analyze a single block from its logs
+func (ix *chainIndexer) logBlockAnalizer(header *types.Header) (customTypes.BlockData, error) {
+	hash := header.Hash()
+	logs, err := ix.client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		BlockHash: &hash,
+		Topics:    ix.eventTopics(),
+	})
+	if err != nil {
+		return customTypes.BlockData{}, err
+	}
+
+	blocks, err := ix.logsAnalizer(logs, header)
+	if err != nil {
+		return customTypes.BlockData{}, err
+	}
+	data := customTypes.BlockData{Block: ix.blockStruct(header)}
+	if len(blocks) > 0 {
+		data = blocks[0]
+	}
+	// Blocks are only traced when they are followed one by one, the historical sync relies on the transfers
+	err = ix.traceCreations(&data)
+	return data, err
+}
+
+// Scan [from, to) with eth_getLogs, halving the range when the node rejects it and growing it back after a success
+func (ix *chainIndexer) syncLogs(from uint64, to uint64) error {
+	size := ix.chain.LogRange
+	for from < to {
+		end := from + size
+		if end > to {
+			end = to
+		}
+
+		logs, err := ix.client.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(end - 1),
+			Topics:    ix.eventTopics(),
+		})
+		if rpcclient.IsRangeTooLarge(err) && size > 1 {
+			size /= 2
+			ix.log.Println("Log range too large, retrying with", size, "blocks")
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		blocks, err := ix.logsAnalizer(logs, nil)
+		if err != nil {
+			return err
+		}
+		last, err := ix.client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(end-1))
+		if err != nil {
+			return err
+		}
+		err = ix.store.ApplyRange(context.Background(), blocks, ix.blockStruct(last))
+		if err != nil {
+			return err
+		}
+		ix.log.Println("Synced up to block", end-1)
+
+		from = end
+		size *= 2
+		if size > ix.chain.LogRangeMax {
+			size = ix.chain.LogRangeMax
+		}
+	}
+	return nil
+}
@@ -2,267 +2,635 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 	"log"
 	"math/big"
+	"os"
 	"sync"
 
 	"workspace/config"
 	"workspace/customTypes"
-
 	"workspace/database"
+	"workspace/metadata"
+	"workspace/rpcclient"
+	"workspace/sales"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-	_ "github.com/lib/pq"
 	"github.com/metachris/eth-go-bindings/erc165"
 	"github.com/metachris/eth-go-bindings/erc721"
 )
 
+// Configuration loaded at start-up
+var cfg *config.Config
+
 var EVT_TRANSFER = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
 
-func detectERC721Deployment(tx *types.Transaction, client *ethclient.Client) (common.Address, error) {
-	signer := types.LatestSignerForChainID(tx.ChainId())
-	sender, err := signer.Sender(tx)
+// ERC-165 interface ids of the indexed standards
+var ERC721_INTERFACE = [4]byte{0x80, 0xac, 0x58, 0xcd}
+var ERC1155_INTERFACE = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+
+// Check if a contract implements an interface through ERC-165
+func supportsInterface(contractAddress common.Address, interfaceId [4]byte, client *rpcclient.Client) (bool, error) {
+	contract, err := erc165.NewErc165(contractAddress, client)
 	if err != nil {
-		return common.Address{}, err
+		return false, err
 	}
-	contractAddress := crypto.CreateAddress(sender, tx.Nonce())
+	return contract.SupportsInterface(nil, interfaceId)
+}
 
-	contract, err := erc165.NewErc165(contractAddress, client)
+// Get the standard of a contract, erc721, erc1155 or an empty string
+func contractStandard(contractAddress common.Address, client *rpcclient.Client) (string, error) {
+	isERC721, err := supportsInterface(contractAddress, ERC721_INTERFACE, client)
 	if err != nil {
-		return common.Address{}, err
+		return "", err
 	}
-	isERC721, err := contract.SupportsInterface(nil, [4]byte{0x80, 0xac, 0x58, 0xcd})
-	if err == nil && isERC721 {
-		return contractAddress, nil
+	if isERC721 {
+		return "erc721", nil
 	}
-	return common.Address{}, err
+	isERC1155, err := supportsInterface(contractAddress, ERC1155_INTERFACE, client)
+	if err != nil || !isERC1155 {
+		return "", err
+	}
+	return "erc1155", nil
 }
 
-func eventChecker(tx *types.Transaction, block *types.Block, client *ethclient.Client, db *sql.DB) (common.Address, error) {
-	// Get tx receipt
-	receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
+func detectDeployment(tx *types.Transaction, client *rpcclient.Client) (common.Address, string, error) {
+	contractAddress, err := creationAddress(tx)
 	if err != nil {
-		return common.Address{}, err
+		return common.Address{}, "", err
 	}
 
-	for _, vLog := range receipt.Logs {
-		topic := vLog.Topics[0]
-		if topic == EVT_TRANSFER {
-			offset := 0
-			// Check if the len is two (=> it's not a transfer event)
-			if len(vLog.Topics) <= 3 {
-				continue
-			}
+	standard, err := contractStandard(contractAddress, client)
+	if err != nil || standard == "" {
+		return common.Address{}, "", err
+	}
+	return contractAddress, standard, nil
+}
 
-			// Check if there is a fourth argument in the topic
-			if len(vLog.Topics) == 5 {
-				offset = 1
-			}
+// Build a collection from its deployment, name and symbol are read from the contract. deployTx is empty when it is unknown
+func (ix *chainIndexer) collectionChecker(addr common.Address, deployTx common.Hash, timestamp uint64, blockNumber uint64) (customTypes.ERC721CollectionStruct, error) {
+	// Get the collection Name and Symbol from the contract
+	erc721, err := erc721.NewErc721(addr, ix.client)
+	if err != nil {
+		return customTypes.ERC721CollectionStruct{}, err
+	}
+	// Transient RPC errors are already retried by the client, an error here means the optional metadata extension is missing
+	name, err := erc721.Name(nil)
+	if err != nil {
+		name = ""
+	}
+	symbol, err := erc721.Symbol(nil)
+	if err != nil {
+		symbol = ""
+	}
+	deployTxHash := ""
+	if deployTx != (common.Hash{}) {
+		deployTxHash = deployTx.Hex()
+	}
 
-			txTag := "transfer"
-			if vLog.Topics[1+offset].Hex()[26:] == common.HexToAddress("0x0").Hex()[2:] {
-				txTag = "mint"
-			} else if vLog.Topics[2+offset].Hex()[26:] == common.HexToAddress("0x0").Hex()[2:] {
-				txTag = "burn"
-			}
+	return customTypes.ERC721CollectionStruct{
+		ChainId:           ix.chain.ChainID,
+		ContractAddress:   addr,
+		ContractName:      name,
+		ContractSymbol:    symbol,
+		DeployTimestamp:   timestamp,
+		DeployBlockNumber: blockNumber,
+		DeployTxHash:      deployTxHash,
+	}, nil
+}
 
-			tokenId := vLog.Topics[3+offset].Big()
-
-			tx := customTypes.ERC721TxStruct{
-				Timestamp:   block.Time(),
-				BlockNumber: block.Number().Uint64(),
-				TxHash:      tx.Hash().Hex(),
-				Tag:         txTag,
-				FromAddr:    common.HexToAddress(vLog.Topics[1+offset].Hex()[26:]),
-				ToAddr:      common.HexToAddress(vLog.Topics[2+offset].Hex()[26:]),
-				Value:       tx.Value().String(),
-				TokenId:     tokenId.String(),
-				Collection:  common.HexToAddress(vLog.Address.Hex()),
-			}
-			err := database.InsertTx(db, tx)
+// Record a deployed collection in the table of its standard
+func (ix *chainIndexer) addCollection(addr common.Address, standard string, deployTx common.Hash, timestamp uint64, blockNumber uint64, data *customTypes.BlockData) error {
+	collection, err := ix.collectionChecker(addr, deployTx, timestamp, blockNumber)
+	if err != nil {
+		return err
+	}
+	// Insert a collection, it is remembered once its block is ordered
+	if standard == "erc1155" {
+		data.ERC1155Collections = append(data.ERC1155Collections, customTypes.ERC1155CollectionStruct(collection))
+	} else {
+		data.Collections = append(data.Collections, collection)
+	}
+	return nil
+}
 
-			if txTag == "transfer" {
-				database.UpdateOwner(db, tx)
-			}
-			if txTag == "mint" {
-				erc721, err := erc721.NewErc721(tx.Collection, client)
-				if err != nil {
-					log.Fatalln(err)
-				}
-				uri, err := erc721.TokenURI(nil, tokenId)
-				if err != nil {
-					uri = ""
-				}
+// Decode an ERC-721 Transfer log, value is the native value of its transaction
+func (ix *chainIndexer) transferChecker(vLog *types.Log, timestamp uint64, data *customTypes.BlockData) error {
+	// Check if the len is two (=> it's not a transfer event)
+	if len(vLog.Topics) <= 3 || vLog.Topics[0] != EVT_TRANSFER {
+		return nil
+	}
 
-				nft := customTypes.ERC721Struct{
-					MintTimestamp:   block.Time(),
-					MintBlockNumber: block.Number().Uint64(),
-					MintTxHash:      tx.TxHash,
-					URI:             uri,
-					TokenId:         tx.TokenId,
-					Collection:      tx.Collection,
-					Owner:           tx.ToAddr,
-				}
+	offset := 0
+	// Check if there is a fourth argument in the topic
+	if len(vLog.Topics) == 5 {
+		offset = 1
+	}
 
-				database.InsertMint(db, nft)
-			}
+	txTag := "transfer"
+	if vLog.Topics[1+offset].Hex()[26:] == common.HexToAddress("0x0").Hex()[2:] {
+		txTag = "mint"
+	} else if vLog.Topics[2+offset].Hex()[26:] == common.HexToAddress("0x0").Hex()[2:] {
+		txTag = "burn"
+	}
 
-			if err != nil {
-				return common.Address{}, err
-			}
+	tokenId := vLog.Topics[3+offset].Big()
+
+	tx := customTypes.ERC721TxStruct{
+		ChainId:     ix.chain.ChainID,
+		Timestamp:   timestamp,
+		BlockNumber: vLog.BlockNumber,
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+		Tag:         txTag,
+		FromAddr:    common.HexToAddress(vLog.Topics[1+offset].Hex()[26:]),
+		ToAddr:      common.HexToAddress(vLog.Topics[2+offset].Hex()[26:]),
+		TokenId:     tokenId.String(),
+		Collection:  common.HexToAddress(vLog.Address.Hex()),
+	}
+	data.Txs = append(data.Txs, tx)
+
+	if txTag == "mint" {
+		erc721, err := erc721.NewErc721(tx.Collection, ix.client)
+		if err != nil {
+			return err
+		}
+		uri, err := erc721.TokenURI(nil, tokenId)
+		if err != nil {
+			uri = ""
 		}
 
+		nft := customTypes.ERC721Struct{
+			ChainId:         ix.chain.ChainID,
+			MintTimestamp:   timestamp,
+			MintBlockNumber: vLog.BlockNumber,
+			MintTxHash:      tx.TxHash,
+			URI:             uri,
+			TokenId:         tx.TokenId,
+			Collection:      tx.Collection,
+			Owner:           tx.ToAddr,
+		}
+
+		data.Mints = append(data.Mints, nft)
 	}
-	return common.Address{}, nil
+	return nil
 }
 
-func blockAnalizer(block *types.Block, client *ethclient.Client, db *sql.DB) {
-	for _, tx := range block.Transactions() {
+func (ix *chainIndexer) eventChecker(tx *types.Transaction, receipt *types.Receipt, block *types.Block, data *customTypes.BlockData) error {
+	for _, vLog := range receipt.Logs {
+		if len(vLog.Topics) == 0 {
+			continue
+		}
+		err := ix.discoverCollection(vLog, receipt, data)
+		if err != nil {
+			return err
+		}
+		err = ix.transferChecker(vLog, block.Time(), data)
+		if err != nil {
+			return err
+		}
+		err = ix.erc1155Checker(vLog, block.Time(), data)
+		if err != nil {
+			return err
+		}
+		ix.saleChecker(vLog, block.Time(), data)
+	}
+	return nil
+}
+
+// Record the NFTs sold by a marketplace log
+func (ix *chainIndexer) saleChecker(vLog *types.Log, timestamp uint64, data *customTypes.BlockData) {
+	fills, err := ix.sales.Decode(vLog)
+	if err != nil {
+		// Any contract can emit an event with the signature of a marketplace, a malformed one is not a sale
+		ix.log.Println("Skipped sale :", err)
+		return
+	}
+
+	for i, fill := range fills {
+		sale := customTypes.SaleStruct{
+			ChainId:     ix.chain.ChainID,
+			Timestamp:   timestamp,
+			BlockNumber: vLog.BlockNumber,
+			TxHash:      vLog.TxHash.Hex(),
+			LogIndex:    vLog.Index,
+			ItemIndex:   i,
+			Marketplace: fill.Marketplace,
+			Collection:  fill.Collection,
+			TokenId:     fill.TokenId.String(),
+			Amount:      fill.Amount.String(),
+			Seller:      fill.Seller,
+			Buyer:       fill.Buyer,
+			Currency:    fill.Currency,
+			Price:       fill.Price.String(),
+		}
+		// Matched orders emit one event per side, the token is only sold once
+		if hasSale(data, sale) {
+			continue
+		}
+		data.Sales = append(data.Sales, sale)
+	}
+}
+
+func hasSale(data *customTypes.BlockData, sale customTypes.SaleStruct) bool {
+	for _, other := range data.Sales {
+		if other.TxHash == sale.TxHash && other.Collection == sale.Collection && other.TokenId == sale.TokenId {
+			return true
+		}
+	}
+	return false
+}
+
+// Collect everything that must be written for a block
+func (ix *chainIndexer) blockAnalizer(block *types.Block) (customTypes.BlockData, error) {
+	data := customTypes.BlockData{Block: ix.blockStruct(block.Header())}
+
+	// Get the receipts of the whole block at once
+	receipts, err := ix.client.BlockReceipts(context.Background(), block)
+	if err != nil {
+		return data, err
+	}
+
+	// Collections created by other contracts
+	err = ix.traceCreations(&data)
+	if err != nil {
+		return data, err
+	}
+
+	payments := map[common.Hash]*payment{}
+	for i, tx := range block.Transactions() {
+		payments[tx.Hash()] = &payment{tx: tx, erc20: erc20Logs(receipts[i].Logs)}
 		// if it's a deployment transaction, the to field will be nil
 		if tx.To() == nil {
-			addr, err := detectERC721Deployment(tx, client)
+			// The logs of the transaction are still checked when its contract cannot be inspected
+			addr, standard, err := detectDeployment(tx, ix.client)
 			if err != nil {
-				continue
-			}
-			if addr != (common.Address{}) {
-				// Get the collection Name and Symbol from the contract
-				erc721, err := erc721.NewErc721(addr, client)
-				if err != nil {
-					log.Fatalln(err)
-				}
-				name, err := erc721.Name(nil)
+				ix.log.Println("Deployment", tx.Hash().Hex(), "not checked :", err)
+			} else if standard != "" {
+				err = ix.addCollection(addr, standard, tx.Hash(), block.Time(), block.NumberU64(), &data)
 				if err != nil {
-					log.Fatalln(err)
+					return data, err
 				}
-				symbol, err := erc721.Symbol(nil)
-				if err != nil {
-					log.Fatalln(err)
-				}
-
-				// Insert a collection
-				database.InserCollection(db, customTypes.ERC721CollectionStruct{
-					ContractAddress:   addr,
-					ContractName:      name,
-					ContractSymbol:    symbol,
-					DeployTimestamp:   block.Time(),
-					DeployBlockNumber: block.Number().Uint64(),
-					DeployTxHash:      tx.Hash().Hex(),
-				})
 			}
 		}
-		eventChecker(tx, block, client, db)
+		err := ix.eventChecker(tx, receipts[i], block, &data)
+		if err != nil {
+			return data, err
+		}
+	}
+	ix.priceTransfers(&data, payments)
+	return data, nil
+}
+
+func (ix *chainIndexer) blockStruct(header *types.Header) customTypes.BlockStruct {
+	return customTypes.BlockStruct{
+		ChainId:    ix.chain.ChainID,
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash().Hex(),
+		ParentHash: header.ParentHash.Hex(),
+		Timestamp:  header.Time,
 	}
-	log.Println("Block", block.Number().Uint64(), "done")
 }
 
-func query(client *ethclient.Client, blockNb uint64, db *sql.DB) {
-	block, err := client.BlockByNumber(context.Background(), big.NewInt(int64(blockNb)))
+// Analyze a block with the configured ingestion mode and write it with its checkpoint in one transaction
+func (ix *chainIndexer) indexBlock(header *types.Header) error {
+	var data customTypes.BlockData
+	var err error
+	if ix.chain.IngestMode == "logs" {
+		data, err = ix.logBlockAnalizer(header)
+	} else {
+		var block *types.Block
+		block, err = ix.client.BlockByHash(context.Background(), header.Hash())
+		if err != nil {
+			return err
+		}
+		data, err = ix.blockAnalizer(block)
+	}
 	if err != nil {
-		log.Fatalln(err)
+		return err
+	}
+
+	err = ix.store.ApplyBlock(context.Background(), data)
+	if err != nil {
+		return err
 	}
-	blockAnalizer(block, client, db)
+	ix.rememberCollections(&data)
+	ix.log.Println("Block", header.Number.Uint64(), "done")
+	return nil
+}
+
+// Indexer of a single chain, every chain has its own client and checkpoint
+type chainIndexer struct {
+	chain  config.ChainConfig
+	client *rpcclient.Client
+	store  database.Store
+	log    *log.Logger
+	// Contracts already checked, true for the collections
+	collections sync.Map
+	// Decoders of the enabled marketplaces
+	sales *sales.Registry
 }
 
-func startClient() (*ethclient.Client, error) {
-	endpoint := config.INFURA_KEY
-	client, err := ethclient.Dial(endpoint)
+func newChainIndexer(chain config.ChainConfig, store database.Store) (*chainIndexer, error) {
+	ix := &chainIndexer{
+		chain: chain,
+		store: store,
+		log:   log.New(os.Stderr, "["+chain.Name+"] ", log.LstdFlags),
+	}
+
+	registry, err := sales.NewRegistry(chain.Marketplaces)
+	if err != nil {
+		return nil, fmt.Errorf("chain %s: %w", chain.Name, err)
+	}
+	ix.sales = registry
+
+	client, err := rpcclient.Dial(chain.RPCURLs, chain.RPCRateLimit, chain.RPCMaxRetries, chain.RPCRetryDelay)
 	if err != nil {
 		return nil, err
 	}
-	return client, nil
-}
+	ix.client = client
 
-func syncDatabase(client *ethclient.Client, db *sql.DB) {
-	// Current block
-	currentBlock, err := client.BlockNumber(context.Background())
+	// Every row is tagged with the chain id so the endpoints must agree with the configuration
+	chainId, err := client.ChainID(context.Background())
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
-	syncedBlock, err := database.SelectBlock(db)
+	if ix.chain.ChainID == 0 {
+		ix.chain.ChainID = chainId.Uint64()
+	} else if ix.chain.ChainID != chainId.Uint64() {
+		return nil, fmt.Errorf("chain %s: endpoints serve chain id %d, expected %d", chain.Name, chainId.Uint64(), ix.chain.ChainID)
+	}
+
+	method, err := client.DetectReceiptsMethod(context.Background(), chain.ReceiptsMethod)
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
+	ix.log.Println("Receipts fetched with method :", method)
+	return ix, nil
+}
 
-	var wg sync.WaitGroup
+// Sync the chain then follow its head until a fatal error
+func (ix *chainIndexer) run() {
 	// Sync the database
-	for i := syncedBlock; i < currentBlock; i++ {
-		wg.Add(1)
-		go func(i uint64) {
-			defer wg.Done()
-			query(client, i, db)
-		}(i)
+	ix.syncDatabase()
 
-		if i%1000 == 0 {
-			wg.Wait()
-			log.Println("Synced up to block", i)
-			err := database.UpdateBlock(db, i)
+	// Listen for new blocks
+	headers := make(chan *types.Header)
+	sub, err := ix.client.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		ix.log.Fatalln(err)
+	}
+
+	for {
+		select {
+		case err := <-sub.Err():
+			ix.log.Fatalln(err)
+		case <-headers:
+			// Blocks are applied in order so that reorgs can be detected against the stored chain
+			err := ix.followChain()
 			if err != nil {
-				log.Fatalln(err)
+				ix.log.Fatalln(err)
 			}
 		}
+	}
+}
 
-		if i == currentBlock-1 {
-			currentBlock, err = client.BlockNumber(context.Background())
-			if err != nil {
-				log.Fatalln(err)
+// Result of a fetch worker
+type fetchedBlock struct {
+	number uint64
+	data   customTypes.BlockData
+	err    error
+}
+
+func (ix *chainIndexer) fetchBlock(number uint64) (customTypes.BlockData, error) {
+	block, err := ix.client.BlockByNumber(context.Background(), new(big.Int).SetUint64(number))
+	if err != nil {
+		return customTypes.BlockData{}, err
+	}
+	return ix.blockAnalizer(block)
+}
+
+// Fetch and analyze the blocks in parallel but apply them in block order so that owners are always correct.
+// The consecutive blocks ready to be written are applied together, up to write_batch blocks per transaction.
+// A block that does not extend the previous one rolls the chain back to the common ancestor, the sync goes on
+// from synced: to, or the ancestor after a rollback
+func (ix *chainIndexer) syncRange(from uint64, to uint64) (synced uint64, err error) {
+	if from >= to {
+		return to, nil
+	}
+	// Hash of the parent of the next block to apply, unknown when it is not stored
+	var parentHash string
+	if from > 0 {
+		parentHash, _, err = ix.store.SelectBlockHash(context.Background(), ix.chain.ChainID, from-1)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	numbers := make(chan uint64)
+	// Bounds the number of blocks fetched ahead of the next one to commit
+	window := make(chan struct{}, 4*ix.chain.FetchWorkers)
+	// The fetched blocks wait here while a batch is written
+	results := make(chan fetchedBlock, cap(window))
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		defer close(numbers)
+		for number := from; number < to; number++ {
+			select {
+			case window <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case numbers <- number:
+			case <-done:
+				return
 			}
 		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < ix.chain.FetchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range numbers {
+				data, err := ix.fetchBlock(number)
+				select {
+				case results <- fetchedBlock{number: number, data: data, err: err}:
+				case <-done:
+					return
+				}
+			}
+		}()
 	}
-	wg.Wait()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := map[uint64]fetchedBlock{}
+	next := from
+	var batch []customTypes.BlockData
+	for result := range results {
+		pending[result.number] = result
+		// Take the other fetched blocks so that they join the batch
+		for taken := true; taken; {
+			select {
+			case result, taken = <-results:
+				if taken {
+					pending[result.number] = result
+				}
+			default:
+				taken = false
+			}
+		}
+		for {
+			ready, ok := pending[next]
+			if ok {
+				delete(pending, next)
+				if ready.err != nil || (parentHash != "" && ready.data.Block.ParentHash != parentHash) {
+					// The blocks before it are kept
+					if len(batch) > 0 {
+						err := ix.applyBatch(batch)
+						if err != nil {
+							return 0, err
+						}
+					}
+					if ready.err != nil {
+						return 0, fmt.Errorf("block %d: %w", next, ready.err)
+					}
+					return ix.rollbackFrom(next)
+				}
+				parentHash = ready.data.Block.Hash
+				batch = append(batch, ready.data)
+				<-window
+				next++
+			}
+			// Written once full, or when the next block is not fetched yet so that the checkpoint follows the sync
+			if len(batch) > 0 && (!ok || len(batch) == ix.chain.WriteBatch) {
+				err := ix.applyBatch(batch)
+				if err != nil {
+					return 0, err
+				}
+				batch = batch[:0]
+			}
+			if !ok {
+				break
+			}
+		}
+	}
+	return to, nil
+}
 
-	log.Println("Synced")
+// Roll back the stored chain to the common ancestor of a block that does not extend it, like followChain
+func (ix *chainIndexer) rollbackFrom(number uint64) (ancestor uint64, err error) {
+	ancestor, err = ix.findCommonAncestor(number - 1)
+	if err != nil {
+		return 0, err
+	}
+	ix.log.Println("Reorg detected at block", number, "rolling back to", ancestor)
+	err = ix.store.Rollback(context.Background(), ix.chain.ChainID, ancestor)
+	ix.forgetCollections()
+	if err != nil {
+		return 0, err
+	}
+	return ancestor, nil
 }
 
-func main() {
-	db, err := database.StartDatabase()
+// Write consecutive blocks and move the checkpoint to the last one in a single transaction
+func (ix *chainIndexer) applyBatch(batch []customTypes.BlockData) error {
+	first, last := batch[0].Block.Number, batch[len(batch)-1].Block
+	err := ix.store.ApplyRange(context.Background(), batch, last)
 	if err != nil {
-		log.Fatalln(err)
+		return fmt.Errorf("blocks %d to %d: %w", first, last.Number, err)
 	}
-	defer db.Close()
+	for i := range batch {
+		ix.rememberCollections(&batch[i])
+	}
+	if first%1000 == 0 || first/1000 != last.Number/1000 {
+		ix.log.Println("Synced up to block", last.Number)
+	}
+	return nil
+}
 
-	client, err := startClient()
+func (ix *chainIndexer) syncDatabase() {
+	// Drop the blocks orphaned while the indexer was stopped
+	err := ix.checkStoredTip()
 	if err != nil {
-		log.Fatalln(err)
+		ix.log.Fatalln(err)
+	}
+
+	syncedBlock, err := ix.store.SelectBlock(context.Background(), ix.chain.ChainID, ix.chain.StartBlock)
+	if err != nil {
+		ix.log.Fatalln(err)
+	}
+
+	// Sync until the head stops moving away
+	for {
+		currentBlock, err := ix.headBlock()
+		if err != nil {
+			ix.log.Fatalln(err)
+		}
+		if syncedBlock >= currentBlock {
+			break
+		}
+		next := currentBlock
+		if ix.chain.IngestMode == "logs" {
+			err = ix.syncLogs(syncedBlock, currentBlock)
+		} else {
+			next, err = ix.syncRange(syncedBlock, currentBlock)
+		}
+		if err != nil {
+			ix.log.Fatalln(err)
+		}
+		syncedBlock = next
 	}
 
-	// tx 0x6e8f8ee72ef5d07c91d78876e916be3b017c6f28358dd0543336019f75f9024f
-	// for i := 500; i < 600; i++ {
-	// 	bi := big.NewInt(int64(i))
-	// 	block, _ := client.BlockByNumber(context.Background(), bi)
-	// 	blockAnalizer(block, client, db)
-	// }
-	// // tx, _, _ := client.TransactionByHash(context.Background(), common.HexToHash("0xede8f5012c5115534103b9ed25cc1cdfaeb41e661a7a4813a4e6e721922b616d"))
-	// // block, _ := client.BlockByNumber(context.Background(), big.NewInt(4228))
-	// // eventChecker(tx, block, client, db)
+	ix.log.Println("Synced")
+}
 
-	// log.Fatal("Done")
+func main() {
+	var args []string
+	var err error
+	cfg, args, err = config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalln(err)
+	}
+	database.IgnoreErr = cfg.IgnoreErr
 
-	// Sync the database
-	syncDatabase(client, db)
+	if runCommand(args) {
+		return
+	}
 
-	// Listen for new blocks
-	headers := make(chan *types.Header)
-	sub, err := client.SubscribeNewHead(context.Background(), headers)
+	store, err := database.StartDatabase(cfg.DatabaseDriver, cfg.DatabaseURL, cfg.DBConnections)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	defer store.Close()
 
-	for {
-		select {
-		case err := <-sub.Err():
+	if cfg.MetadataWorkers > 0 {
+		fetcher := metadata.NewFetcher(cfg.IPFSGateways, cfg.ArweaveGateways, cfg.MetadataTimeout)
+		worker := metadata.NewWorker(store, fetcher, cfg.MetadataWorkers, cfg.MetadataMaxAttempts, cfg.MetadataRetryDelay, log.New(os.Stderr, "[metadata] ", log.LstdFlags))
+		go worker.Run(context.Background())
+	}
+
+	// One indexer per chain, they only share the database
+	var wg sync.WaitGroup
+	for _, chain := range cfg.Chains {
+		ix, err := newChainIndexer(chain, store)
+		if err != nil {
 			log.Fatalln(err)
-		case header := <-headers:
-			go func() {
-				block, err := client.BlockByHash(context.Background(), header.Hash())
-				if err != nil {
-					log.Fatalln(err)
-				}
-				blockAnalizer(block, client, db)
-			}()
 		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ix.run()
+		}()
 	}
+	wg.Wait()
 }
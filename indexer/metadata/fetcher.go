@@ -0,0 +1,405 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"workspace/customTypes"
+)
+
+// Documents larger than this are rejected
+const maxDocumentSize = 1 << 20
+
+// Error that would happen again on retry: malformed URI or document, or a gateway answering 4xx
+type PermanentError struct {
+	err error
+}
+
+func (e *PermanentError) Error() string { return e.err.Error() }
+func (e *PermanentError) Unwrap() error { return e.err }
+
+func permanent(format string, args ...any) error {
+	return &PermanentError{err: fmt.Errorf(format, args...)}
+}
+
+// Check if a fetch must not be retried
+func IsPermanent(err error) bool {
+	var permanentErr *PermanentError
+	return errors.As(err, &permanentErr)
+}
+
+// Resolves token URIs and parses their metadata documents
+type Fetcher struct {
+	client          *http.Client
+	ipfsGateways    []string
+	arweaveGateways []string
+	// host:port of the configured gateways, they may be on a private network
+	trustedHosts map[string]bool
+}
+
+func NewFetcher(ipfsGateways []string, arweaveGateways []string, timeout time.Duration) *Fetcher {
+	f := &Fetcher{
+		ipfsGateways:    withSlash(ipfsGateways),
+		arweaveGateways: withSlash(arweaveGateways),
+		trustedHosts:    map[string]bool{},
+	}
+	for _, gateway := range append(f.ipfsGateways, f.arweaveGateways...) {
+		if host := hostPort(gateway); host != "" {
+			f.trustedHosts[host] = true
+		}
+	}
+	// Every connection goes through dial, redirects included
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = f.dial
+	f.client = &http.Client{Timeout: timeout, Transport: transport}
+	return f
+}
+
+// host:port of a URL, with the default port of its scheme
+func hostPort(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(strings.ToLower(parsed.Hostname()), port)
+}
+
+var errPrivateAddress = errors.New("refusing to fetch from a non-public address")
+
+// Token URIs are chosen by anyone deploying a contract, they must not reach the loopback, private or link-local
+// networks of the indexer (cloud metadata services included). The host is resolved here and the checked address is
+// dialed so that it cannot resolve to another one in between
+func (f *Fetcher) dial(ctx context.Context, network string, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if f.trustedHosts[net.JoinHostPort(strings.ToLower(host), port)] {
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	var dialErr error
+	for _, ip := range ips {
+		if !isPublic(ip) {
+			return nil, fmt.Errorf("%s resolves to %s: %w", host, ip, errPrivateAddress)
+		}
+	}
+	for _, ip := range ips {
+		var conn net.Conn
+		conn, dialErr = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if dialErr == nil {
+			return conn, nil
+		}
+	}
+	if dialErr == nil {
+		dialErr = fmt.Errorf("%s has no address", host)
+	}
+	return nil, dialErr
+}
+
+// "This network" and the shared address space of the carrier-grade NATs, not covered by IsPrivate
+var reservedPrefixes = []netip.Prefix{netip.MustParsePrefix("0.0.0.0/8"), netip.MustParsePrefix("100.64.0.0/10")}
+
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func withSlash(gateways []string) []string {
+	var result []string
+	for _, gateway := range gateways {
+		if !strings.HasSuffix(gateway, "/") {
+			gateway += "/"
+		}
+		result = append(result, gateway)
+	}
+	return result
+}
+
+// Fetch and parse the metadata document of a token URI
+func (f *Fetcher) Fetch(ctx context.Context, uri string) (customTypes.MetadataStruct, error) {
+	uri = strings.TrimSpace(uri)
+	var document []byte
+	var err error
+	if strings.HasPrefix(uri, "data:") {
+		document, err = decodeDataURI(uri)
+	} else {
+		document, err = f.download(ctx, uri)
+	}
+	if err != nil {
+		return customTypes.MetadataStruct{}, err
+	}
+	return f.parse(document)
+}
+
+// Decode a data: URI, base64 or percent encoded
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, found := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !found {
+		return nil, permanent("malformed data URI")
+	}
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			// Some contracts do not pad their base64
+			decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "="))
+		}
+		if err != nil {
+			return nil, permanent("invalid base64 data URI: %w", err)
+		}
+		return decoded, nil
+	}
+	// utf8 JSON is often stored without escaping, keep the payload when it is not valid percent encoding
+	decoded, err := url.PathUnescape(payload)
+	if err != nil {
+		return []byte(payload), nil
+	}
+	return []byte(decoded), nil
+}
+
+// Get the URLs a URI can be downloaded from, ipfs and arweave content is tried on every gateway
+func (f *Fetcher) candidates(uri string) ([]string, error) {
+	switch {
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+		return gatewayURLs(f.ipfsGateways, path), nil
+	case strings.HasPrefix(uri, "ar://"):
+		return gatewayURLs(f.arweaveGateways, strings.TrimPrefix(uri, "ar://")), nil
+	case strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://"):
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, permanent("invalid URI: %w", err)
+		}
+		// Public gateways baked into URIs are often rate limited, ours are tried first
+		if _, path, found := strings.Cut(parsed.EscapedPath(), "/ipfs/"); found {
+			if parsed.RawQuery != "" {
+				path += "?" + parsed.RawQuery
+			}
+			return append(gatewayURLs(f.ipfsGateways, path), uri), nil
+		}
+		return []string{uri}, nil
+	default:
+		return nil, permanent("unsupported URI scheme")
+	}
+}
+
+func gatewayURLs(gateways []string, path string) []string {
+	var urls []string
+	for _, gateway := range gateways {
+		urls = append(urls, gateway+path)
+	}
+	return urls
+}
+
+// Download a document from the first URL that answers it
+func (f *Fetcher) download(ctx context.Context, uri string) ([]byte, error) {
+	urls, err := f.candidates(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, target := range urls {
+		document, err := f.get(ctx, target)
+		if err == nil {
+			return document, nil
+		}
+		errs = append(errs, err)
+	}
+	// The fetch is only given up when every gateway refused it
+	for _, err := range errs {
+		if !IsPermanent(err) {
+			// Flattened so the permanent errors of the other gateways are not found by IsPermanent
+			return nil, errors.New(errors.Join(errs...).Error())
+		}
+	}
+	return nil, &PermanentError{err: errors.Join(errs...)}
+}
+
+func (f *Fetcher) get(ctx context.Context, target string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, permanent("invalid URL: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := f.client.Do(request)
+	if errors.Is(err, errPrivateAddress) {
+		return nil, &PermanentError{err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s answered %s", request.URL.Host, response.Status)
+		if response.StatusCode >= 400 && response.StatusCode < 500 && response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusRequestTimeout {
+			return nil, &PermanentError{err: err}
+		}
+		return nil, err
+	}
+
+	document, err := io.ReadAll(io.LimitReader(response.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(document) > maxDocumentSize {
+		return nil, permanent("%s returned a document larger than %d bytes", request.URL.Host, maxDocumentSize)
+	}
+	return document, nil
+}
+
+// Fields read from the metadata standard of ERC-721, OpenSea extensions included
+type document struct {
+	Name        json.RawMessage `json:"name"`
+	Description json.RawMessage `json:"description"`
+	Image       string          `json:"image"`
+	ImageURL    string          `json:"image_url"`
+	ImageData   string          `json:"image_data"`
+	Attributes  json.RawMessage `json:"attributes"`
+	Traits      json.RawMessage `json:"traits"`
+}
+
+func (f *Fetcher) parse(raw []byte) (customTypes.MetadataStruct, error) {
+	raw = bytes.TrimPrefix(bytes.TrimSpace(raw), []byte("\xef\xbb\xbf"))
+	var parsed document
+	err := json.Unmarshal(raw, &parsed)
+	if err != nil {
+		return customTypes.MetadataStruct{}, permanent("invalid metadata document: %w", err)
+	}
+	compacted := new(bytes.Buffer)
+	err = json.Compact(compacted, raw)
+	if err != nil {
+		return customTypes.MetadataStruct{}, permanent("invalid metadata document: %w", err)
+	}
+
+	metadata := customTypes.MetadataStruct{
+		Name:        text(parsed.Name),
+		Description: text(parsed.Description),
+		Image:       parsed.Image,
+		Attributes:  parsed.Attributes,
+		Document:    compacted.Bytes(),
+	}
+	if metadata.Image == "" {
+		metadata.Image = parsed.ImageURL
+	}
+	if metadata.Image == "" && parsed.ImageData != "" {
+		metadata.Image = "data:image/svg+xml;utf8," + url.PathEscape(parsed.ImageData)
+	}
+	metadata.Image = f.publicURL(metadata.Image)
+	if len(metadata.Attributes) == 0 || string(metadata.Attributes) == "null" {
+		metadata.Attributes = parsed.Traits
+	}
+	if string(metadata.Attributes) == "null" {
+		metadata.Attributes = nil
+	}
+	metadata.Traits = traits(metadata.Attributes)
+	return metadata, nil
+}
+
+// Read the traits of an attributes list, [{"trait_type": ..., "value": ...}] or {"trait type": value}.
+// Attributes with a display_type are numbers, dates or boosts and are not traits.
+func traits(attributes json.RawMessage) []customTypes.TraitStruct {
+	var result []customTypes.TraitStruct
+	add := func(traitType json.RawMessage, value json.RawMessage) {
+		trait := customTypes.TraitStruct{TraitType: strings.TrimSpace(scalar(traitType)), Value: strings.TrimSpace(scalar(value))}
+		if trait.TraitType != "" && trait.Value != "" {
+			result = append(result, trait)
+		}
+	}
+
+	var list []struct {
+		TraitType   json.RawMessage `json:"trait_type"`
+		Value       json.RawMessage `json:"value"`
+		DisplayType string          `json:"display_type"`
+	}
+	if json.Unmarshal(attributes, &list) == nil {
+		for _, attribute := range list {
+			if attribute.DisplayType == "" {
+				add(attribute.TraitType, attribute.Value)
+			}
+		}
+		return result
+	}
+
+	var object map[string]json.RawMessage
+	if json.Unmarshal(attributes, &object) == nil {
+		for traitType, value := range object {
+			add(json.RawMessage(strconv.Quote(traitType)), value)
+		}
+	}
+	return result
+}
+
+// Text of a string, number or boolean JSON value, empty for the other values
+func scalar(value json.RawMessage) string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	var number json.Number
+	if json.Unmarshal(value, &number) == nil {
+		return number.String()
+	}
+	var b bool
+	if json.Unmarshal(value, &b) == nil {
+		return strconv.FormatBool(b)
+	}
+	return ""
+}
+
+// Names and descriptions are sometimes numbers
+func text(value json.RawMessage) string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	if len(value) == 0 || string(value) == "null" {
+		return ""
+	}
+	return string(value)
+}
+
+// Rewrite ipfs:// and ar:// URLs to the first gateway so that browsers can load them
+func (f *Fetcher) publicURL(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "ipfs://") && len(f.ipfsGateways) > 0:
+		return f.ipfsGateways[0] + strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+	case strings.HasPrefix(uri, "ar://") && len(f.arweaveGateways) > 0:
+		return f.arweaveGateways[0] + strings.TrimPrefix(uri, "ar://")
+	}
+	return uri
+}
@@ -0,0 +1,161 @@
+package metadata
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testDocument = `{"name": "Token #1", "description": 7, "image": "ipfs://QmImage/1.png", "attributes": [{"trait_type": "Background", "value": "Blue"}, {"trait_type": "Level", "value": 3, "display_type": "number"}]}`
+
+// Local stand-in for the IPFS and Arweave gateways, paths are answered from documents
+type gateway struct {
+	server   *httptest.Server
+	requests atomic.Int32
+}
+
+func newGateway(t *testing.T, documents map[string]string) *gateway {
+	g := &gateway{}
+	g.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.requests.Add(1)
+		document, ok := documents[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(document))
+	}))
+	t.Cleanup(g.server.Close)
+	return g
+}
+
+func failingGateway(t *testing.T, status int) *gateway {
+	g := &gateway{}
+	g.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.requests.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(g.server.Close)
+	return g
+}
+
+func TestFetchURIs(t *testing.T) {
+	ipfs := newGateway(t, map[string]string{"/ipfs/QmDocument/1": testDocument})
+	arweave := newGateway(t, map[string]string{"/TxId": testDocument})
+	origin := newGateway(t, map[string]string{"/token/1": testDocument})
+	f := NewFetcher([]string{ipfs.server.URL + "/ipfs"}, []string{arweave.server.URL}, time.Second)
+	// Loopback addresses are refused unless trusted like a gateway
+	f.trustedHosts[hostPort(origin.server.URL)] = true
+
+	tests := []struct {
+		name string
+		uri  string
+	}{
+		{"ipfs", "ipfs://QmDocument/1"},
+		{"ipfs with path prefix", "ipfs://ipfs/QmDocument/1"},
+		{"gateway baked into the uri", "https://ipfs.io/ipfs/QmDocument/1"},
+		{"arweave", "ar://TxId"},
+		{"base64 data", "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(testDocument))},
+		{"unpadded base64 data", "data:application/json;base64," + base64.RawStdEncoding.EncodeToString([]byte(testDocument))},
+		{"percent encoded data", "data:application/json," + url.PathEscape(testDocument)},
+		{"utf8 data", "data:application/json;utf8," + testDocument},
+		{"http", origin.server.URL + "/token/1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata, err := f.Fetch(context.Background(), test.uri)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if metadata.Name != "Token #1" || metadata.Description != "7" {
+				t.Errorf("name %q and description %q", metadata.Name, metadata.Description)
+			}
+			if metadata.Image != ipfs.server.URL+"/ipfs/QmImage/1.png" {
+				t.Errorf("image %q, expected it on the first gateway", metadata.Image)
+			}
+			if len(metadata.Traits) != 1 || metadata.Traits[0].TraitType != "Background" || metadata.Traits[0].Value != "Blue" {
+				t.Errorf("traits %+v", metadata.Traits)
+			}
+		})
+	}
+}
+
+func TestFetchTriesEveryGateway(t *testing.T) {
+	down := failingGateway(t, http.StatusBadGateway)
+	up := newGateway(t, map[string]string{"/QmDocument": testDocument})
+	f := NewFetcher([]string{down.server.URL, up.server.URL}, nil, time.Second)
+
+	_, err := f.Fetch(context.Background(), "ipfs://QmDocument")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if down.requests.Load() != 1 || up.requests.Load() != 1 {
+		t.Errorf("%d and %d requests, expected one per gateway", down.requests.Load(), up.requests.Load())
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	notFound := failingGateway(t, http.StatusNotFound)
+	unavailable := failingGateway(t, http.StatusServiceUnavailable)
+	rateLimited := failingGateway(t, http.StatusTooManyRequests)
+	invalid := newGateway(t, map[string]string{"/QmInvalid": "not json"})
+
+	tests := []struct {
+		name      string
+		gateways  []string
+		uri       string
+		permanent bool
+	}{
+		{"not found", []string{notFound.server.URL}, "ipfs://QmDocument", true},
+		{"one gateway unavailable", []string{notFound.server.URL, unavailable.server.URL}, "ipfs://QmDocument", false},
+		{"rate limited", []string{rateLimited.server.URL}, "ipfs://QmDocument", false},
+		{"invalid document", []string{invalid.server.URL}, "ipfs://QmInvalid", true},
+		{"invalid base64", nil, "data:application/json;base64,%%%", true},
+		{"malformed data uri", nil, "data:application/json", true},
+		{"unsupported scheme", nil, "ftp://example.com/1", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := NewFetcher(test.gateways, nil, time.Second)
+			_, err := f.Fetch(context.Background(), test.uri)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if IsPermanent(err) != test.permanent {
+				t.Errorf("permanent is %v, expected %v: %v", IsPermanent(err), test.permanent, err)
+			}
+		})
+	}
+}
+
+func TestFetchRefusesPrivateAddresses(t *testing.T) {
+	private := newGateway(t, map[string]string{"/latest/meta-data": testDocument})
+	// A trusted host redirecting to a private one is refused too
+	redirect := httptest.NewServer(http.RedirectHandler(private.server.URL+"/latest/meta-data", http.StatusFound))
+	t.Cleanup(redirect.Close)
+	f := NewFetcher(nil, nil, time.Second)
+	f.trustedHosts[hostPort(redirect.URL)] = true
+
+	for _, uri := range []string{
+		private.server.URL + "/latest/meta-data",
+		strings.Replace(private.server.URL, "127.0.0.1", "localhost", 1) + "/latest/meta-data",
+		redirect.URL,
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.1/",
+		"http://[::1]/",
+	} {
+		_, err := f.Fetch(context.Background(), uri)
+		if err == nil || !IsPermanent(err) || !strings.Contains(err.Error(), errPrivateAddress.Error()) {
+			t.Errorf("%s: expected a permanent refusal, got %v", uri, err)
+		}
+	}
+	if private.requests.Load() != 0 {
+		t.Errorf("%d requests reached the private server", private.requests.Load())
+	}
+}
@@ -0,0 +1,150 @@
+package metadata
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"workspace/customTypes"
+	"workspace/database"
+)
+
+// Delay before looking for new tokens when nothing is pending
+const pollInterval = 10 * time.Second
+
+// Longest delay between two rarity updates while documents are pending
+const rarityInterval = time.Minute
+
+// Longest delay after database errors, the delay starts at pollInterval and doubles while they last
+const maxErrorDelay = 5 * time.Minute
+
+// Fetches the metadata of the indexed tokens in the background
+type Worker struct {
+	store       database.Store
+	fetcher     *Fetcher
+	workers     int
+	maxAttempts int
+	retryDelay  time.Duration
+	log         *log.Logger
+}
+
+func NewWorker(store database.Store, fetcher *Fetcher, workers int, maxAttempts int, retryDelay time.Duration, logger *log.Logger) *Worker {
+	return &Worker{
+		store:       store,
+		fetcher:     fetcher,
+		workers:     workers,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+		log:         logger,
+	}
+}
+
+// Fetch the pending documents until ctx is done
+func (w *Worker) Run(ctx context.Context) {
+	var rarityUpdated time.Time
+	errorDelay := pollInterval
+	for {
+		tasks, err := w.store.SelectPendingMetadata(ctx, 16*w.workers)
+		if err != nil {
+			w.log.Println("Select pending metadata :", err)
+		}
+		// The tasks whose result could not be written are still pending, they would be selected again at once
+		failed := err != nil
+		if len(tasks) > 0 && !w.process(ctx, tasks) {
+			failed = true
+		}
+		// Ranks are computed for many documents at once rather than after every token
+		if len(tasks) == 0 || time.Since(rarityUpdated) > rarityInterval {
+			w.updateRarity(ctx)
+			rarityUpdated = time.Now()
+		}
+
+		delay := pollInterval
+		if failed {
+			delay = errorDelay
+			errorDelay *= 2
+			if errorDelay > maxErrorDelay {
+				errorDelay = maxErrorDelay
+			}
+		} else {
+			errorDelay = pollInterval
+			if len(tasks) > 0 {
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Fetch the tasks, ok is false when the result of one of them could not be written
+func (w *Worker) process(ctx context.Context, tasks []customTypes.MetadataTask) (ok bool) {
+	queue := make(chan customTypes.MetadataTask)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				if w.fetch(ctx, task) != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	wg.Wait()
+	return !failed.Load()
+}
+
+// Fetch a document and write the result, the error is the one of the database
+func (w *Worker) fetch(ctx context.Context, task customTypes.MetadataTask) error {
+	metadata, err := w.fetcher.Fetch(ctx, task.URI)
+	if err == nil {
+		err = w.store.InsertMetadata(ctx, task, metadata)
+		if err != nil {
+			w.log.Println("Insert metadata :", err)
+		}
+		return err
+	}
+
+	// Retried with an exponential backoff until maxAttempts, a permanent error is given up at once
+	status := "pending"
+	if IsPermanent(err) || task.Attempts+1 >= w.maxAttempts {
+		status = "failed"
+	}
+	delay := w.retryDelay << task.Attempts
+	if delay <= 0 || delay > 24*time.Hour {
+		delay = 24 * time.Hour
+	}
+	err = w.store.UpdateMetadataFailure(ctx, task, status, time.Now().Add(delay), err.Error())
+	if err != nil {
+		w.log.Println("Update metadata :", err)
+	}
+	return err
+}
+
+// Compute the rarity of the collections whose traits changed
+func (w *Worker) updateRarity(ctx context.Context) {
+	collections, err := w.store.SelectStaleRarity(ctx)
+	if err != nil {
+		w.log.Println("Select stale rarity :", err)
+		return
+	}
+	for _, collection := range collections {
+		err = w.store.UpdateRarity(ctx, collection.ChainId, collection.Collection)
+		if err != nil {
+			w.log.Println("Update rarity of", collection.Collection, ":", err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"workspace/customTypes"
+	"workspace/database"
+)
+
+// Records what the worker writes, the other methods of the Store are not used
+type fakeStore struct {
+	database.Store
+	mu        sync.Mutex
+	inserted  []customTypes.MetadataStruct
+	failures  []failure
+	insertErr error
+}
+
+type failure struct {
+	status      string
+	nextAttempt time.Time
+	reason      string
+}
+
+func (s *fakeStore) InsertMetadata(ctx context.Context, task customTypes.MetadataTask, metadata customTypes.MetadataStruct) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.insertErr != nil {
+		return s.insertErr
+	}
+	s.inserted = append(s.inserted, metadata)
+	return nil
+}
+
+func (s *fakeStore) UpdateMetadataFailure(ctx context.Context, task customTypes.MetadataTask, status string, nextAttempt time.Time, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{status, nextAttempt, reason})
+	return nil
+}
+
+func TestWorkerRetries(t *testing.T) {
+	unavailable := failingGateway(t, http.StatusServiceUnavailable)
+	notFound := failingGateway(t, http.StatusNotFound)
+	up := newGateway(t, map[string]string{"/QmDocument": testDocument})
+	const retryDelay = time.Minute
+
+	tests := []struct {
+		name     string
+		gateway  string
+		attempts int
+		status   string
+		// Delay before the next attempt, 0 when the fetch succeeds
+		delay time.Duration
+	}{
+		{"success", up.server.URL, 0, "", 0},
+		{"first failure", unavailable.server.URL, 0, "pending", retryDelay},
+		{"backoff doubles", unavailable.server.URL, 2, "pending", 4 * retryDelay},
+		{"last attempt", unavailable.server.URL, 4, "failed", 16 * retryDelay},
+		{"permanent error", notFound.server.URL, 0, "failed", retryDelay},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &fakeStore{}
+			fetcher := NewFetcher([]string{test.gateway}, nil, time.Second)
+			w := NewWorker(store, fetcher, 1, 5, retryDelay, log.New(io.Discard, "", 0))
+			start := time.Now()
+			err := w.fetch(context.Background(), customTypes.MetadataTask{URI: "ipfs://QmDocument", Attempts: test.attempts})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.status == "" {
+				if len(store.inserted) != 1 || len(store.failures) != 0 {
+					t.Fatalf("%d documents and %d failures written, expected the document", len(store.inserted), len(store.failures))
+				}
+				return
+			}
+			if len(store.inserted) != 0 || len(store.failures) != 1 {
+				t.Fatalf("%d documents and %d failures written, expected the failure", len(store.inserted), len(store.failures))
+			}
+			written := store.failures[0]
+			if written.status != test.status {
+				t.Errorf("status %q, expected %q", written.status, test.status)
+			}
+			if delay := written.nextAttempt.Sub(start); delay < test.delay || delay > test.delay+time.Second {
+				t.Errorf("next attempt in %s, expected %s", delay, test.delay)
+			}
+			if written.reason == "" {
+				t.Error("the error is not recorded")
+			}
+		})
+	}
+}
+
+func TestWorkerReportsWriteErrors(t *testing.T) {
+	up := newGateway(t, map[string]string{"/QmDocument": testDocument})
+	store := &fakeStore{insertErr: errors.New("database is locked")}
+	w := NewWorker(store, NewFetcher([]string{up.server.URL}, nil, time.Second), 2, 5, time.Minute, log.New(io.Discard, "", 0))
+
+	tasks := []customTypes.MetadataTask{{URI: "ipfs://QmDocument"}, {URI: "ipfs://QmDocument"}}
+	if w.process(context.Background(), tasks) {
+		t.Error("process succeeded while the documents could not be written")
+	}
+	store.insertErr = nil
+	if !w.process(context.Background(), tasks) || len(store.inserted) != 2 {
+		t.Errorf("%d documents written, expected 2", len(store.inserted))
+	}
+}
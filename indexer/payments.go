@@ -0,0 +1,272 @@
+package main
+
+import (
+	"math/big"
+
+	"workspace/customTypes"
+	"workspace/sales"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// What a transaction paid, used to price the transfers that no marketplace event covers
+type payment struct {
+	tx *types.Transaction
+	// ERC-20 Transfer logs of the transaction
+	erc20 []*types.Log
+}
+
+// Decode an ERC-20 Transfer, ERC-721 transfers index the token id and have one topic more
+func erc20Transfer(vLog *types.Log) (from common.Address, to common.Address, amount *big.Int, ok bool) {
+	if len(vLog.Topics) != 3 || vLog.Topics[0] != EVT_TRANSFER || len(vLog.Data) != 32 {
+		return from, to, nil, false
+	}
+	from = common.BytesToAddress(vLog.Topics[1].Bytes())
+	to = common.BytesToAddress(vLog.Topics[2].Bytes())
+	return from, to, new(big.Int).SetBytes(vLog.Data), true
+}
+
+// Keep the ERC-20 transfers of a receipt
+func erc20Logs(logs []*types.Log) []*types.Log {
+	var transfers []*types.Log
+	for _, vLog := range logs {
+		if _, _, _, ok := erc20Transfer(vLog); ok {
+			transfers = append(transfers, vLog)
+		}
+	}
+	return transfers
+}
+
+// Tokens received by the same address in a transaction, they are priced together
+type purchase struct {
+	buyer     common.Address
+	transfers []pricedTransfer
+	// Addresses that are paid for the tokens: the sellers, and the collections for the mints
+	payees map[common.Address]bool
+}
+
+// An ERC-721 transfer or a row of an ERC-1155 one, units is the amount of tokens it moves
+type pricedTransfer struct {
+	txHash     string
+	tag        string
+	collection common.Address
+	tokenId    string
+	from       common.Address
+	to         common.Address
+	units      *big.Int
+	// Set the price of one token of the transfer
+	setPrice func(price *big.Int, currency common.Address, source string, confidence string)
+}
+
+func (ix *chainIndexer) pricedTransfers(data *customTypes.BlockData) []pricedTransfer {
+	one := big.NewInt(1)
+	transfers := make([]pricedTransfer, 0, len(data.Txs)+len(data.ERC1155Txs))
+	for i := range data.Txs {
+		tx := &data.Txs[i]
+		transfers = append(transfers, pricedTransfer{tx.TxHash, tx.Tag, tx.Collection, tx.TokenId, tx.FromAddr, tx.ToAddr, one,
+			func(price *big.Int, currency common.Address, source string, confidence string) {
+				tx.Price, tx.Currency, tx.PriceSource, tx.Confidence = price.String(), currency, source, confidence
+			}})
+	}
+	for i := range data.ERC1155Txs {
+		tx := &data.ERC1155Txs[i]
+		units, ok := new(big.Int).SetString(tx.Amount, 10)
+		if !ok || units.Sign() == 0 {
+			continue
+		}
+		transfers = append(transfers, pricedTransfer{tx.TxHash, tx.Tag, tx.Collection, tx.TokenId, tx.FromAddr, tx.ToAddr, units,
+			func(price *big.Int, currency common.Address, source string, confidence string) {
+				tx.Price, tx.Currency, tx.PriceSource, tx.Confidence = price.String(), currency, source, confidence
+			}})
+	}
+	return transfers
+}
+
+// Set the price of the ERC-721 and ERC-1155 transfers of a block. Marketplace sales give the price of the tokens they cover,
+// the other transfers are priced from the ERC-20 tokens their receiver sent to the sender, or from the native value of the transaction.
+func (ix *chainIndexer) priceTransfers(data *customTypes.BlockData, payments map[common.Hash]*payment) {
+	type saleKey struct {
+		txHash     string
+		collection common.Address
+		tokenId    string
+	}
+	sold := map[saleKey]customTypes.SaleStruct{}
+	marketplaceTxs := map[string]bool{}
+	for _, sale := range data.Sales {
+		sold[saleKey{sale.TxHash, sale.Collection, sale.TokenId}] = sale
+		marketplaceTxs[sale.TxHash] = true
+	}
+
+	var purchases []*purchase
+	byBuyer := map[string]*purchase{}
+	for _, transfer := range ix.pricedTransfers(data) {
+		if sale, ok := sold[saleKey{transfer.txHash, transfer.collection, transfer.tokenId}]; ok {
+			amount, _ := new(big.Int).SetString(sale.Amount, 10)
+			price, _ := new(big.Int).SetString(sale.Price, 10)
+			if amount != nil && price != nil && amount.Sign() > 0 {
+				transfer.setPrice(price.Quo(price, amount), sale.Currency, "marketplace", "high")
+			}
+			continue
+		}
+		// The payments of a marketplace transaction are already counted by its sales
+		if transfer.tag == "burn" || marketplaceTxs[transfer.txHash] {
+			continue
+		}
+
+		key := transfer.txHash + transfer.to.Hex()
+		p, ok := byBuyer[key]
+		if !ok {
+			p = &purchase{buyer: transfer.to, payees: map[common.Address]bool{}}
+			byBuyer[key] = p
+			purchases = append(purchases, p)
+		}
+		p.transfers = append(p.transfers, transfer)
+		if transfer.tag == "mint" {
+			p.payees[transfer.collection] = true
+		} else {
+			p.payees[transfer.from] = true
+		}
+	}
+
+	buyers := map[common.Hash]int{}
+	for _, p := range purchases {
+		buyers[common.HexToHash(p.transfers[0].txHash)]++
+	}
+	for _, p := range purchases {
+		hash := common.HexToHash(p.transfers[0].txHash)
+		pay, ok := payments[hash]
+		if !ok {
+			continue
+		}
+		total, currency, source, confidence := ix.pricePurchase(p, pay, buyers[hash])
+		if total == nil {
+			continue
+		}
+		for j, price := range unitPrices(total, p.transfers) {
+			p.transfers[j].setPrice(price, currency, source, confidence)
+		}
+	}
+}
+
+// Price of one token of each transfer of a purchase. The total is split evenly between the tokens,
+// rounded down when some transfers move several tokens
+func unitPrices(total *big.Int, transfers []pricedTransfer) []*big.Int {
+	units := new(big.Int)
+	for _, transfer := range transfers {
+		units.Add(units, transfer.units)
+	}
+	if units.IsInt64() && units.Int64() == int64(len(transfers)) {
+		return sales.Split(total, len(transfers))
+	}
+	price := new(big.Int).Quo(total, units)
+	prices := make([]*big.Int, len(transfers))
+	for i := range prices {
+		prices[i] = price
+	}
+	return prices
+}
+
+// Find what the buyer paid for its tokens, total is nil when no payment can be attributed to them.
+// The native value sent by the buyer is added to its ERC-20 payment when that is the wrapped native currency (wrapped_native),
+// otherwise the payment in the two currencies is ambiguous and only the ERC-20 one is kept
+func (ix *chainIndexer) pricePurchase(p *purchase, pay *payment, buyers int) (total *big.Int, currency common.Address, source string, confidence string) {
+	paid, currency, confidence := erc20Payment(p, pay)
+	value, valueConfidence := ix.nativePayment(p, pay, buyers)
+	switch {
+	case paid != nil && value != nil && currency == common.HexToAddress(ix.chain.WrappedNative) && ix.chain.WrappedNative != "":
+		return paid.Add(paid, value), common.Address{}, "native", lowest(confidence, valueConfidence)
+	case paid != nil && value != nil:
+		return paid, currency, "erc20", "low"
+	case paid != nil:
+		return paid, currency, "erc20", confidence
+	case value != nil:
+		return value, common.Address{}, "native", valueConfidence
+	}
+	return nil, currency, "", ""
+}
+
+// ERC-20 tokens sent by the buyer to the payees and to the contract called by the transaction, which keeps the fees.
+// The currency is the first one sent to a payee, the transfers to other addresses are left out and lower the confidence
+func erc20Payment(p *purchase, pay *payment) (total *big.Int, currency common.Address, confidence string) {
+	for _, vLog := range pay.erc20 {
+		from, to, _, _ := erc20Transfer(vLog)
+		if from == p.buyer && p.payees[to] {
+			currency = vLog.Address
+			break
+		}
+	}
+	if currency == (common.Address{}) {
+		return nil, currency, ""
+	}
+
+	recipients := map[common.Address]bool{}
+	for payee := range p.payees {
+		recipients[payee] = true
+	}
+	if to := pay.tx.To(); to != nil && *to != p.buyer {
+		recipients[*to] = true
+	}
+	total = new(big.Int)
+	ambiguous := false
+	for _, vLog := range pay.erc20 {
+		from, to, amount, _ := erc20Transfer(vLog)
+		if from != p.buyer {
+			continue
+		}
+		if vLog.Address == currency && recipients[to] {
+			total.Add(total, amount)
+		} else {
+			ambiguous = true
+		}
+	}
+	confidence = "high"
+	if len(p.payees) > 1 {
+		confidence = "medium"
+	}
+	if ambiguous {
+		confidence = lower(confidence)
+	}
+	return total, currency, confidence
+}
+
+// Value of the transaction when it can be attributed to the buyer, internal transfers of the native currency are not visible without traces
+func (ix *chainIndexer) nativePayment(p *purchase, pay *payment, buyers int) (value *big.Int, confidence string) {
+	if pay.tx.Value().Sign() == 0 {
+		return nil, ""
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(new(big.Int).SetUint64(ix.chain.ChainID)), pay.tx)
+	switch {
+	case err == nil && sender == p.buyer && pay.tx.To() != nil && p.payees[*pay.tx.To()] && len(p.payees) == 1:
+		confidence = "high"
+	case err == nil && sender == p.buyer:
+		confidence = "medium"
+	case buyers == 1:
+		// Bought for another address
+		confidence = "low"
+	default:
+		return nil, ""
+	}
+	return new(big.Int).Set(pay.tx.Value()), confidence
+}
+
+var confidences = []string{"low", "medium", "high"}
+
+// Confidence one step below, low stays low
+func lower(confidence string) string {
+	for i := 1; i < len(confidences); i++ {
+		if confidences[i] == confidence {
+			return confidences[i-1]
+		}
+	}
+	return "low"
+}
+
+func lowest(a string, b string) string {
+	for _, confidence := range confidences {
+		if a == confidence || b == confidence {
+			return confidence
+		}
+	}
+	return "low"
+}
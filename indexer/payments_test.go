@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"workspace/config"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	weth     = common.HexToAddress("0x00000000000000000000000000000000000000e1")
+	usdc     = common.HexToAddress("0x00000000000000000000000000000000000000e2")
+	seller   = common.HexToAddress("0x00000000000000000000000000000000000000a2")
+	market   = common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	stranger = common.HexToAddress("0x00000000000000000000000000000000000000f2")
+)
+
+func erc20Log(token common.Address, from common.Address, to common.Address, amount int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{EVT_TRANSFER, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(big.NewInt(amount)).Bytes(),
+	}
+}
+
+func TestPricePurchase(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buyer := crypto.PubkeyToAddress(key.PublicKey)
+	ix := &chainIndexer{chain: config.ChainConfig{ChainID: 1, WrappedNative: weth.Hex()}}
+	signed := func(to common.Address, value int64) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(0, to, big.NewInt(value), 100000, big.NewInt(1), nil), types.LatestSignerForChainID(big.NewInt(1)), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	tests := []struct {
+		name       string
+		pay        payment
+		total      int64
+		currency   common.Address
+		source     string
+		confidence string
+	}{
+		{"paid to the seller", payment{signed(seller, 0), []*types.Log{erc20Log(usdc, buyer, seller, 100)}}, 100, usdc, "erc20", "high"},
+		{"fee kept by the called contract", payment{signed(market, 0), []*types.Log{erc20Log(usdc, buyer, seller, 95), erc20Log(usdc, buyer, market, 5)}}, 100, usdc, "erc20", "high"},
+		{"unrelated transfer left out", payment{signed(market, 0), []*types.Log{erc20Log(usdc, buyer, seller, 100), erc20Log(usdc, buyer, stranger, 900)}}, 100, usdc, "erc20", "medium"},
+		{"native value and wrapped native", payment{signed(market, 40), []*types.Log{erc20Log(weth, buyer, seller, 60)}}, 100, common.Address{}, "native", "medium"},
+		{"native value and another token", payment{signed(market, 40), []*types.Log{erc20Log(usdc, buyer, seller, 60)}}, 60, usdc, "erc20", "low"},
+		{"native value to the seller", payment{signed(seller, 40), nil}, 40, common.Address{}, "native", "high"},
+	}
+	for _, test := range tests {
+		p := &purchase{buyer: buyer, payees: map[common.Address]bool{seller: true}}
+		total, currency, source, confidence := ix.pricePurchase(p, &test.pay, 1)
+		if total == nil || total.Int64() != test.total || currency != test.currency || source != test.source || confidence != test.confidence {
+			t.Errorf("%s: priced %v %s %s %s, expected %d %s %s %s", test.name, total, currency.Hex(), source, confidence, test.total, test.currency.Hex(), test.source, test.confidence)
+		}
+	}
+}
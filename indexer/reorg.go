@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Get the highest block that can be indexed according to head_tag and confirmations
+func (ix *chainIndexer) headBlock() (uint64, error) {
+	var tag rpc.BlockNumber
+	switch ix.chain.HeadTag {
+	case "latest":
+		tag = rpc.LatestBlockNumber
+	case "safe":
+		tag = rpc.SafeBlockNumber
+	case "finalized":
+		tag = rpc.FinalizedBlockNumber
+	default:
+		return 0, fmt.Errorf("unknown head tag %q", ix.chain.HeadTag)
+	}
+
+	header, err := ix.client.HeaderByNumber(context.Background(), big.NewInt(int64(tag)))
+	if err != nil {
+		return 0, err
+	}
+	head := header.Number.Uint64()
+	if head < ix.chain.Confirmations {
+		return 0, nil
+	}
+	return head - ix.chain.Confirmations, nil
+}
+
+// Walk back the stored blocks from a block until one matches the canonical chain
+func (ix *chainIndexer) findCommonAncestor(from uint64) (uint64, error) {
+	number := from
+	for {
+		stored, hash, found, err := ix.store.SelectBlockAtOrBelow(context.Background(), ix.chain.ChainID, number)
+		if err != nil {
+			return 0, err
+		}
+		// Nothing was indexed below, there is nothing to compare with
+		if !found {
+			return number, nil
+		}
+
+		header, err := ix.client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(stored))
+		if err != nil {
+			return 0, err
+		}
+		if header.Hash().Hex() == hash || stored == 0 {
+			return stored, nil
+		}
+		number = stored - 1
+	}
+}
+
+// Roll back the database if the last stored block is no longer canonical
+func (ix *chainIndexer) checkStoredTip() error {
+	last, found, err := ix.store.SelectLastBlock(context.Background(), ix.chain.ChainID)
+	if err != nil || !found {
+		return err
+	}
+
+	ancestor, err := ix.findCommonAncestor(last)
+	if err != nil {
+		return err
+	}
+	if ancestor == last {
+		return nil
+	}
+
+	ix.log.Println("Stored chain diverged, rolling back from block", last, "to", ancestor)
+	err = ix.store.Rollback(context.Background(), ix.chain.ChainID, ancestor)
+	ix.forgetCollections()
+	return err
+}
+
+// Index the blocks between the last stored block and the followed head, handling reorgs
+func (ix *chainIndexer) followChain() error {
+	target, err := ix.headBlock()
+	if err != nil {
+		return err
+	}
+
+	next, err := ix.store.SelectBlock(context.Background(), ix.chain.ChainID, ix.chain.StartBlock)
+	if err != nil {
+		return err
+	}
+	last, found, err := ix.store.SelectLastBlock(context.Background(), ix.chain.ChainID)
+	if err != nil {
+		return err
+	}
+	if found {
+		next = last + 1
+	}
+
+	for next <= target {
+		header, err := ix.client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(next))
+		if err != nil {
+			return err
+		}
+
+		if next > 0 {
+			parentHash, found, err := ix.store.SelectBlockHash(context.Background(), ix.chain.ChainID, next-1)
+			if err != nil {
+				return err
+			}
+			// The new block does not extend the stored chain
+			if found && parentHash != header.ParentHash.Hex() {
+				ancestor, err := ix.findCommonAncestor(next - 1)
+				if err != nil {
+					return err
+				}
+				ix.log.Println("Reorg detected at block", next, "rolling back to", ancestor)
+				err = ix.store.Rollback(context.Background(), ix.chain.ChainID, ancestor)
+				ix.forgetCollections()
+				if err != nil {
+					return err
+				}
+				next = ancestor + 1
+				continue
+			}
+		}
+
+		err = ix.indexBlock(header)
+		if err != nil {
+			return err
+		}
+		next++
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Ethereum client sharing a rate limit between all its requests and retrying the transient errors on the next endpoint.
+// Contract bindings created with it also go through the limit because CallContract and CodeAt are wrapped.
+type Client struct {
+	// Node used for subscriptions and the requests that are not wrapped, a websocket one when available
+	*ethclient.Client
+	nodes      []*ethclient.Client
+	current    atomic.Int64
+	limiter    *limiter
+	maxRetries int
+	retryDelay time.Duration
+	// Set by DetectReceiptsMethod, receipts are fetched one by one until then
+	receiptsMethod string
+}
+
+// Connect to every endpoint of a chain, requestsPerSecond <= 0 disables the rate limit
+func Dial(endpoints []string, requestsPerSecond int, maxRetries int, retryDelay time.Duration) (*Client, error) {
+	c := &Client{
+		limiter:        newLimiter(requestsPerSecond),
+		maxRetries:     maxRetries,
+		retryDelay:     retryDelay,
+		receiptsMethod: ReceiptsSingle,
+	}
+	for i, endpoint := range endpoints {
+		node, err := ethclient.Dial(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %d: %w", i+1, err)
+		}
+		c.nodes = append(c.nodes, node)
+		if c.Client == nil && (strings.HasPrefix(endpoint, "ws://") || strings.HasPrefix(endpoint, "wss://")) {
+			c.Client = node
+		}
+	}
+	if len(c.nodes) == 0 {
+		return nil, errors.New("no endpoint")
+	}
+	if c.Client == nil {
+		c.Client = c.nodes[0]
+	}
+	return c, nil
+}
+
+func (c *Client) Close() {
+	for _, node := range c.nodes {
+		node.Close()
+	}
+}
+
+func (c *Client) ChainID(ctx context.Context) (chainId *big.Int, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		chainId, err = node.ChainID(ctx)
+		return err
+	})
+	return chainId, err
+}
+
+func (c *Client) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		number, err = node.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		block, err = node.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		header, err = node.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		block, err = node.BlockByHash(ctx, hash)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (header *types.Header, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		header, err = node.HeaderByHash(ctx, hash)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		tx, isPending, err = node.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		logs, err = node.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (receipt *types.Receipt, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		receipt, err = node.TransactionReceipt(ctx, hash)
+		return err
+	})
+	return receipt, err
+}
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		result, err = node.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		code, err = node.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// Run a request when the rate limit allows it, retrying with an exponential backoff and moving to the next endpoint
+func (c *Client) do(ctx context.Context, request func(node *ethclient.Client) error) error {
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		err := c.limiter.wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		current := c.current.Load()
+		err = request(c.nodes[current%int64(len(c.nodes))])
+		if err == nil || !isTransient(err) {
+			return err
+		}
+		if attempt >= c.maxRetries {
+			return &TransientError{err}
+		}
+		// Concurrent failures on the same endpoint only move to the next one once
+		c.current.CompareAndSwap(current, current+1)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+const maxRetryDelay = 30 * time.Second
+
+// Error of a request still failing after its retries without a definitive answer of the node, asking again later may succeed
+type TransientError struct {
+	err error
+}
+
+func (e *TransientError) Error() string { return e.err.Error() }
+func (e *TransientError) Unwrap() error { return e.err }
+
+// Check if a request failed without an answer of the node, unlike a reverted call or a missing contract
+func IsTransient(err error) bool {
+	var transient *TransientError
+	return errors.As(err, &transient)
+}
+
+// Check if an error is worth retrying: rate limits, server and transport errors and data the node does not have yet
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	// Retrying the same log range would fail again, the caller must shrink it
+	if IsRangeTooLarge(err) {
+		return false
+	}
+
+	// The node answered, only retry when it asks to slow down. A reverted call is an answer, not a failure
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// -32005 is the limit exceeded code used by Infura and most providers
+		if rpcErr.ErrorCode() == -32005 {
+			return true
+		}
+		message := strings.ToLower(rpcErr.Error())
+		return strings.Contains(message, "rate limit") || strings.Contains(message, "too many requests")
+	}
+
+	// Anything else did not come from the node, the connection failed
+	return true
+}
+
+// Check if a node refused a log query because the block range or the result set is too large
+func IsRangeTooLarge(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	message := strings.ToLower(rpcErr.Error())
+	for _, hint := range []string{"more than", "too many results", "block range", "range limit", "range too", "response size", "query timeout"} {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package rpcclient
+
+import (
+	"context"
+	"time"
+)
+
+// Token bucket refilled every second, a nil limiter never waits
+type limiter struct {
+	tokens chan struct{}
+}
+
+func newLimiter(requestsPerSecond int) *limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	l := &limiter{tokens: make(chan struct{}, requestsPerSecond)}
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(requestsPerSecond))
+		defer ticker.Stop()
+		for range ticker.C {
+			select {
+			case l.tokens <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return l
+}
+
+// Block until a request can be sent
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case <-l.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,141 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Ways of fetching the receipts of a block, from the cheapest to the most expensive
+const (
+	ReceiptsBlock  = "block"  // one eth_getBlockReceipts call
+	ReceiptsBatch  = "batch"  // JSON-RPC batches of eth_getTransactionReceipt
+	ReceiptsSingle = "single" // one eth_getTransactionReceipt call per transaction
+)
+
+// Maximum number of requests sent in one JSON-RPC batch
+const maxBatchSize = 100
+
+// Select how receipts are fetched. "auto" probes the node for eth_getBlockReceipts, then for batch support.
+func (c *Client) DetectReceiptsMethod(ctx context.Context, method string) (string, error) {
+	switch method {
+	case ReceiptsBlock, ReceiptsBatch, ReceiptsSingle:
+		c.receiptsMethod = method
+		return method, nil
+	case "auto":
+	default:
+		return "", fmt.Errorf("unknown receipts method %q", method)
+	}
+
+	var receipts []*types.Receipt
+	err := c.do(ctx, func(node *ethclient.Client) error {
+		return node.Client().CallContext(ctx, &receipts, "eth_getBlockReceipts", "latest")
+	})
+	if err == nil {
+		c.receiptsMethod = ReceiptsBlock
+		return c.receiptsMethod, nil
+	}
+	if !isUnsupported(err) {
+		return "", err
+	}
+
+	var number string
+	batch := []rpc.BatchElem{{Method: "eth_blockNumber", Result: &number}}
+	err = c.do(ctx, func(node *ethclient.Client) error {
+		return node.Client().BatchCallContext(ctx, batch)
+	})
+	if err == nil && batch[0].Error == nil {
+		c.receiptsMethod = ReceiptsBatch
+	} else {
+		c.receiptsMethod = ReceiptsSingle
+	}
+	return c.receiptsMethod, nil
+}
+
+// Get the receipts of every transaction of a block, in transaction order
+func (c *Client) BlockReceipts(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
+	txs := block.Transactions()
+	receipts := make([]*types.Receipt, len(txs))
+
+	switch c.receiptsMethod {
+	case ReceiptsBlock:
+		var result []*types.Receipt
+		err := c.do(ctx, func(node *ethclient.Client) error {
+			return node.Client().CallContext(ctx, &result, "eth_getBlockReceipts", block.Hash())
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(result) != len(txs) {
+			return nil, fmt.Errorf("block %d has %d transactions but %d receipts", block.NumberU64(), len(txs), len(result))
+		}
+		return result, nil
+
+	case ReceiptsBatch:
+		for start := 0; start < len(txs); start += maxBatchSize {
+			end := start + maxBatchSize
+			if end > len(txs) {
+				end = len(txs)
+			}
+			err := c.do(ctx, func(node *ethclient.Client) error {
+				batch := make([]rpc.BatchElem, end-start)
+				for i := range batch {
+					// Every request of a batch counts against the rate limit
+					if i > 0 {
+						err := c.limiter.wait(ctx)
+						if err != nil {
+							return err
+						}
+					}
+					batch[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []any{txs[start+i].Hash()}, Result: &receipts[start+i]}
+				}
+				err := node.Client().BatchCallContext(ctx, batch)
+				if err != nil {
+					return err
+				}
+				for i, elem := range batch {
+					if elem.Error != nil {
+						return elem.Error
+					}
+					if receipts[start+i] == nil {
+						return ethereum.NotFound
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return receipts, nil
+
+	default:
+		for i, tx := range txs {
+			receipt, err := c.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, err
+			}
+			receipts[i] = receipt
+		}
+		return receipts, nil
+	}
+}
+
+// Check if a node rejected a request because it does not implement the method
+func isUnsupported(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	if rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	message := strings.ToLower(rpcErr.Error())
+	return strings.Contains(message, "not supported") || strings.Contains(message, "does not exist") || strings.Contains(message, "not available") || strings.Contains(message, "method not found")
+}
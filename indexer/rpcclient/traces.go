@@ -0,0 +1,146 @@
+package rpcclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Ways of listing the contracts created by a block, including the creations made by other contracts
+const (
+	TraceNone  = "none"  // contracts are only found from top-level creations and their logs
+	TraceDebug = "debug" // debug_traceBlockByNumber with the callTracer, geth and erigon
+	TraceBlock = "trace" // trace_block, erigon, nethermind and openethereum
+)
+
+// Contract created by a transaction
+type Creation struct {
+	Address common.Address
+	TxHash  common.Hash
+}
+
+// Frame of the callTracer output
+type callFrame struct {
+	Type  string         `json:"type"`
+	To    common.Address `json:"to"`
+	Error string         `json:"error"`
+	Calls []callFrame    `json:"calls"`
+}
+
+// Action of the trace_block output
+type traceAction struct {
+	Type            string       `json:"type"`
+	Error           string       `json:"error"`
+	TraceAddress    []int        `json:"traceAddress"`
+	TransactionHash *common.Hash `json:"transactionHash"`
+	Result          *struct {
+		Address common.Address `json:"address"`
+	} `json:"result"`
+}
+
+// List the successful contract creations of a block with the given trace method
+func (c *Client) BlockCreations(ctx context.Context, method string, number uint64) ([]Creation, error) {
+	switch method {
+	case TraceDebug:
+		var traces []struct {
+			TxHash *common.Hash `json:"txHash"`
+			Result callFrame    `json:"result"`
+		}
+		err := c.do(ctx, func(node *ethclient.Client) error {
+			return node.Client().CallContext(ctx, &traces, "debug_traceBlockByNumber", hexutil.EncodeUint64(number), map[string]string{"tracer": "callTracer"})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Old nodes do not return the hash, the traces are in transaction order
+		if len(traces) > 0 && traces[0].TxHash == nil {
+			block, err := c.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				return nil, err
+			}
+			if len(block.Transactions()) != len(traces) {
+				return nil, fmt.Errorf("block %d has %d transactions but %d traces", number, len(block.Transactions()), len(traces))
+			}
+			for i, tx := range block.Transactions() {
+				hash := tx.Hash()
+				traces[i].TxHash = &hash
+			}
+		}
+
+		var creations []Creation
+		for _, trace := range traces {
+			creations = appendCreations(creations, trace.Result, *trace.TxHash)
+		}
+		return creations, nil
+
+	case TraceBlock:
+		var actions []traceAction
+		err := c.do(ctx, func(node *ethclient.Client) error {
+			return node.Client().CallContext(ctx, &actions, "trace_block", hexutil.EncodeUint64(number))
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var creations []Creation
+		// The frames under a failed one are reverted with it
+		failed := map[common.Hash][][]int{}
+		for _, action := range actions {
+			if action.TransactionHash == nil {
+				// Block rewards
+				continue
+			}
+			hash := *action.TransactionHash
+			if action.Error != "" {
+				failed[hash] = append(failed[hash], action.TraceAddress)
+				continue
+			}
+			if action.Type != "create" || action.Result == nil || underFailed(failed[hash], action.TraceAddress) {
+				continue
+			}
+			creations = append(creations, Creation{Address: action.Result.Address, TxHash: hash})
+		}
+		return creations, nil
+
+	default:
+		return nil, fmt.Errorf("unknown trace method %q", method)
+	}
+}
+
+// Collect the creations of a call tree, the calls of a failed frame are reverted with it
+func appendCreations(creations []Creation, frame callFrame, txHash common.Hash) []Creation {
+	if frame.Error != "" {
+		return creations
+	}
+	if frame.Type == "CREATE" || frame.Type == "CREATE2" {
+		creations = append(creations, Creation{Address: frame.To, TxHash: txHash})
+	}
+	for _, call := range frame.Calls {
+		creations = appendCreations(creations, call, txHash)
+	}
+	return creations
+}
+
+func underFailed(failed [][]int, traceAddress []int) bool {
+	for _, prefix := range failed {
+		if len(prefix) > len(traceAddress) {
+			continue
+		}
+		match := true
+		for i := range prefix {
+			if prefix[i] != traceAddress[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package sales
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	Register(element{})
+}
+
+const elementFees = `{"name":"fees","type":"tuple[]","components":[{"name":"recipient","type":"address"},{"name":"amount","type":"uint256"}]}`
+
+const elementABI = `[
+{"type":"event","name":"ERC721SellOrderFilled","inputs":[
+	{"name":"orderHash","type":"bytes32"},{"name":"maker","type":"address"},{"name":"taker","type":"address"},{"name":"nonce","type":"uint256"},
+	{"name":"erc20Token","type":"address"},{"name":"erc20TokenAmount","type":"uint256"},` + elementFees + `,
+	{"name":"erc721Token","type":"address"},{"name":"erc721TokenId","type":"uint256"}]},
+{"type":"event","name":"ERC721BuyOrderFilled","inputs":[
+	{"name":"orderHash","type":"bytes32"},{"name":"maker","type":"address"},{"name":"taker","type":"address"},{"name":"nonce","type":"uint256"},
+	{"name":"erc20Token","type":"address"},{"name":"erc20TokenAmount","type":"uint256"},` + elementFees + `,
+	{"name":"erc721Token","type":"address"},{"name":"erc721TokenId","type":"uint256"}]},
+{"type":"event","name":"ERC1155SellOrderFilled","inputs":[
+	{"name":"orderHash","type":"bytes32"},{"name":"maker","type":"address"},{"name":"taker","type":"address"},{"name":"nonce","type":"uint256"},
+	{"name":"erc20Token","type":"address"},{"name":"erc20FillAmount","type":"uint256"},` + elementFees + `,
+	{"name":"erc1155Token","type":"address"},{"name":"erc1155TokenId","type":"uint256"},{"name":"erc1155FillAmount","type":"uint128"}]},
+{"type":"event","name":"ERC1155BuyOrderFilled","inputs":[
+	{"name":"orderHash","type":"bytes32"},{"name":"maker","type":"address"},{"name":"taker","type":"address"},{"name":"nonce","type":"uint256"},
+	{"name":"erc20Token","type":"address"},{"name":"erc20FillAmount","type":"uint256"},` + elementFees + `,
+	{"name":"erc1155Token","type":"address"},{"name":"erc1155TokenId","type":"uint256"},{"name":"erc1155FillAmount","type":"uint128"}]}
+]`
+
+var elementEvents = mustParseABI(elementABI)
+
+// Element uses this address for the native currency
+var elementNative = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// Element, the 0x v4 NFT order events. The exchange address differs between chains so any contract is accepted
+type element struct{}
+
+func (element) Name() string { return "element" }
+
+func (element) Topics() []common.Hash {
+	var topics []common.Hash
+	for _, name := range []string{"ERC721SellOrderFilled", "ERC721BuyOrderFilled", "ERC1155SellOrderFilled", "ERC1155BuyOrderFilled"} {
+		topics = append(topics, elementEvents.Events[name].ID)
+	}
+	return topics
+}
+
+func (element) Contracts() []common.Address { return nil }
+
+// The maker of a sell order is the seller, the maker of a buy order is the buyer.
+// The fees are paid on top of the amount received by the seller.
+func (element) Decode(vLog *types.Log) ([]Fill, error) {
+	event, err := elementEvents.EventByID(vLog.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	values, err := event.Inputs.Unpack(vLog.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < 9 {
+		return nil, errors.New("unexpected fields")
+	}
+
+	maker := values[1].(common.Address)
+	taker := values[2].(common.Address)
+	currency := values[4].(common.Address)
+	price := new(big.Int).Set(values[5].(*big.Int))
+	for _, fee := range feeAmounts(values[6]) {
+		price.Add(price, fee)
+	}
+	if currency == elementNative {
+		currency = common.Address{}
+	}
+
+	fill := Fill{
+		Collection: values[7].(common.Address),
+		TokenId:    values[8].(*big.Int),
+		Amount:     big.NewInt(1),
+		Seller:     maker,
+		Buyer:      taker,
+		Currency:   currency,
+		Price:      price,
+	}
+	if len(values) > 9 {
+		fill.Amount = values[9].(*big.Int)
+	}
+	if event.Name == "ERC721BuyOrderFilled" || event.Name == "ERC1155BuyOrderFilled" {
+		fill.Seller, fill.Buyer = taker, maker
+	}
+	return []Fill{fill}, nil
+}
+
+func feeAmounts(value any) []*big.Int {
+	list := reflect.ValueOf(value)
+	amounts := make([]*big.Int, list.Len())
+	for i := range amounts {
+		amounts[i] = list.Index(i).FieldByName("Amount").Interface().(*big.Int)
+	}
+	return amounts
+}
@@ -0,0 +1,131 @@
+package sales
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NFT bought in a sale. Currency is the zero address for the native currency
+type Fill struct {
+	Marketplace string
+	Collection  common.Address
+	TokenId     *big.Int
+	// Number of tokens sold, 1 for ERC-721
+	Amount   *big.Int
+	Seller   common.Address
+	Buyer    common.Address
+	Currency common.Address
+	// Price paid for all the tokens of the fill, fees and royalties included
+	Price *big.Int
+}
+
+// Decodes the sale events of a marketplace
+type Decoder interface {
+	// Name used in the configuration and stored with the sales
+	Name() string
+	// Signatures of the decoded events
+	Topics() []common.Hash
+	// Contracts allowed to emit the events, any contract when empty
+	Contracts() []common.Address
+	// Decode a log whose first topic is one of Topics
+	Decode(vLog *types.Log) ([]Fill, error)
+}
+
+var decoders = map[string]Decoder{}
+
+// Add a marketplace, the decoders register themselves from an init function
+func Register(decoder Decoder) {
+	if _, found := decoders[decoder.Name()]; found {
+		panic("sales: decoder " + decoder.Name() + " registered twice")
+	}
+	decoders[decoder.Name()] = decoder
+}
+
+// Names of the registered marketplaces
+func Names() []string {
+	var names []string
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decoders of the enabled marketplaces indexed by event signature
+type Registry struct {
+	byTopic map[common.Hash][]Decoder
+}
+
+func NewRegistry(names []string) (*Registry, error) {
+	r := &Registry{byTopic: map[common.Hash][]Decoder{}}
+	for _, name := range names {
+		decoder, found := decoders[name]
+		if !found {
+			return nil, fmt.Errorf("unknown marketplace %q, expected one of %v", name, Names())
+		}
+		for _, topic := range decoder.Topics() {
+			r.byTopic[topic] = append(r.byTopic[topic], decoder)
+		}
+	}
+	return r, nil
+}
+
+// Signatures of every decoded event
+func (r *Registry) Topics() []common.Hash {
+	var topics []common.Hash
+	for topic := range r.byTopic {
+		topics = append(topics, topic)
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Hex() < topics[j].Hex() })
+	return topics
+}
+
+// Decode the sales of a log, nil when no enabled marketplace emitted it
+func (r *Registry) Decode(vLog *types.Log) ([]Fill, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, nil
+	}
+	for _, decoder := range r.byTopic[vLog.Topics[0]] {
+		if !allowed(decoder.Contracts(), vLog.Address) {
+			continue
+		}
+		fills, err := decoder.Decode(vLog)
+		if err != nil {
+			return nil, fmt.Errorf("%s log %d of %s: %w", decoder.Name(), vLog.Index, vLog.TxHash.Hex(), err)
+		}
+		for i := range fills {
+			fills[i].Marketplace = decoder.Name()
+		}
+		return fills, nil
+	}
+	return nil, nil
+}
+
+func allowed(contracts []common.Address, address common.Address) bool {
+	if len(contracts) == 0 {
+		return true
+	}
+	for _, contract := range contracts {
+		if contract == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Share price between count items, the remainder goes to the first one so that the total is kept
+func Split(price *big.Int, count int) []*big.Int {
+	shares := make([]*big.Int, count)
+	share, remainder := new(big.Int).DivMod(price, big.NewInt(int64(count)), new(big.Int))
+	for i := range shares {
+		shares[i] = new(big.Int).Set(share)
+	}
+	if count > 0 {
+		shares[0].Add(shares[0], remainder)
+	}
+	return shares
+}
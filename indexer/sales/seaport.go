@@ -0,0 +1,163 @@
+package sales
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	Register(seaport{})
+}
+
+const seaportABI = `[{"type":"event","name":"OrderFulfilled","inputs":[
+	{"name":"orderHash","type":"bytes32","indexed":false},
+	{"name":"offerer","type":"address","indexed":true},
+	{"name":"zone","type":"address","indexed":true},
+	{"name":"recipient","type":"address","indexed":false},
+	{"name":"offer","type":"tuple[]","indexed":false,"components":[
+		{"name":"itemType","type":"uint8"},{"name":"token","type":"address"},{"name":"identifier","type":"uint256"},{"name":"amount","type":"uint256"}]},
+	{"name":"consideration","type":"tuple[]","indexed":false,"components":[
+		{"name":"itemType","type":"uint8"},{"name":"token","type":"address"},{"name":"identifier","type":"uint256"},{"name":"amount","type":"uint256"},{"name":"recipient","type":"address"}]}
+]}]`
+
+var seaportEvents = mustParseABI(seaportABI)
+
+// Seaport item types
+const (
+	itemNative  = 0
+	itemERC20   = 1
+	itemERC721  = 2
+	itemERC1155 = 3
+	// Criteria items are resolved to a token when the order is fulfilled
+	itemERC721WithCriteria  = 4
+	itemERC1155WithCriteria = 5
+)
+
+type seaportItem struct {
+	ItemType   uint8
+	Token      common.Address
+	Identifier *big.Int
+	Amount     *big.Int
+}
+
+// Seaport 1.1 to 1.6, the canonical deployments share their address on every chain
+type seaport struct{}
+
+func (seaport) Name() string { return "seaport" }
+
+func (seaport) Topics() []common.Hash {
+	return []common.Hash{seaportEvents.Events["OrderFulfilled"].ID}
+}
+
+func (seaport) Contracts() []common.Address {
+	return []common.Address{
+		common.HexToAddress("0x00000000006c3852cbEf3e08E8dF289169EdE581"), // 1.1
+		common.HexToAddress("0x00000000000006c7676171937C444f6BDe3D6282"), // 1.2
+		common.HexToAddress("0x0000000000000aD24e80fd803C6ac37206a45f15"), // 1.3
+		common.HexToAddress("0x00000000000001ad428e4906aE43D8F9852d0dD6"), // 1.4
+		common.HexToAddress("0x00000000000000ADc04C56Bf30aC9d3c0aAF14dC"), // 1.5
+		common.HexToAddress("0x0000000000000068F116a894984e2DB1123eB395"), // 1.6
+	}
+}
+
+// A listing offers the NFTs and receives the payment, a bid offers the payment and receives the NFTs.
+// The payment of a bundle is split evenly between its NFTs.
+func (seaport) Decode(vLog *types.Log) ([]Fill, error) {
+	if len(vLog.Topics) != 3 {
+		return nil, errors.New("unexpected topics")
+	}
+	values, err := seaportEvents.Unpack("OrderFulfilled", vLog.Data)
+	if err != nil {
+		return nil, err
+	}
+	offerer := common.BytesToAddress(vLog.Topics[1].Bytes())
+	recipient := values[1].(common.Address)
+	offer := toSeaportItems(values[2])
+	consideration := toSeaportItems(values[3])
+
+	seller, buyer := offerer, recipient
+	nfts, payments := nftItems(offer), paymentItems(consideration)
+	if len(nfts) == 0 {
+		seller, buyer = recipient, offerer
+		nfts, payments = nftItems(consideration), paymentItems(offer)
+	}
+	if len(nfts) == 0 || len(payments) == 0 {
+		// Swaps between NFTs and orders without payment are not sales
+		return nil, nil
+	}
+
+	// Fees and royalties are paid in the currency of the order
+	currency := payments[0].Token
+	total := new(big.Int)
+	for _, payment := range payments {
+		if payment.Token == currency {
+			total.Add(total, payment.Amount)
+		}
+	}
+
+	var fills []Fill
+	for i, share := range Split(total, len(nfts)) {
+		fills = append(fills, Fill{
+			Collection: nfts[i].Token,
+			TokenId:    nfts[i].Identifier,
+			Amount:     nfts[i].Amount,
+			Seller:     seller,
+			Buyer:      buyer,
+			Currency:   currency,
+			Price:      share,
+		})
+	}
+	return fills, nil
+}
+
+// Read the items from the structs generated by the abi package, consideration items also have a recipient
+func toSeaportItems(value any) []seaportItem {
+	list := reflect.ValueOf(value)
+	items := make([]seaportItem, list.Len())
+	for i := range items {
+		item := list.Index(i)
+		items[i] = seaportItem{
+			ItemType:   item.FieldByName("ItemType").Interface().(uint8),
+			Token:      item.FieldByName("Token").Interface().(common.Address),
+			Identifier: item.FieldByName("Identifier").Interface().(*big.Int),
+			Amount:     item.FieldByName("Amount").Interface().(*big.Int),
+		}
+	}
+	return items
+}
+
+func nftItems(items []seaportItem) []seaportItem {
+	var nfts []seaportItem
+	for _, item := range items {
+		switch item.ItemType {
+		case itemERC721, itemERC1155, itemERC721WithCriteria, itemERC1155WithCriteria:
+			nfts = append(nfts, item)
+		}
+	}
+	return nfts
+}
+
+func paymentItems(items []seaportItem) []seaportItem {
+	var payments []seaportItem
+	for _, item := range items {
+		if item.ItemType == itemNative || item.ItemType == itemERC20 {
+			// The native currency is the zero address in Seaport
+			payments = append(payments, item)
+		}
+	}
+	return payments
+}
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}